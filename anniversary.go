@@ -0,0 +1,115 @@
+package datetime
+
+//2月29日的周年日在平年的处理方式
+type LeapDayPolicy int
+
+const (
+	LeapDayFeb28 LeapDayPolicy = iota //平年为2月28日
+	LeapDayMar1                       //平年为3月1日
+)
+
+//@description: 指定年份的周年日 如: 生日, 入会纪念日
+//@param:       year int "年"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      Date "日期"
+func (my Date) Anniversary(year int, policy LeapDayPolicy) Date {
+	if my.Month == 2 && my.Day == 29 && GregorianCalendar.MonthDays(year, 2) < 29 {
+		if policy == LeapDayMar1 {
+			return Date{Year: year, Month: 3, Day: 1}
+		}
+		return Date{Year: year, Month: 2, Day: 28}
+	}
+	return Date{Year: year, Month: my.Month, Day: my.Day}
+}
+
+//@description: from当天或之后的第一个周年日, 不早于my当天
+//@param:       from Date "日期"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      Date "日期"
+func (my Date) NextAnniversary(from Date, policy LeapDayPolicy) Date {
+	if from.Before(my) {
+		return my
+	}
+	next := my.Anniversary(from.Year, policy)
+	if next.Before(from) {
+		next = my.Anniversary(from.Year+1, policy)
+	}
+	return next
+}
+
+//按月加的周年日, 2月29日在平年的2月按policy处理, 其它超出该月天数的日取月末
+func (my Date) addMonthsPolicy(months int, policy LeapDayPolicy) Date {
+	date := my.AddMonths(months)
+	if policy == LeapDayMar1 && my.Month == 2 && my.Day == 29 && date.Month == 2 && date.Day == 28 {
+		return Date{Year: date.Year, Month: 3, Day: 1}
+	}
+	return date
+}
+
+//@description: 从birth到at的年龄(年,月,日), 年数与 DateAgeYears 一致
+//              按月加时日超出该月天数取月末 如: 1月31日到2月28日为1个月
+//              2月29日出生时平年按policy满周岁 如: 2000年2月29日到2001年2月28日, LeapDayFeb28为1年, LeapDayMar1为11个月30天
+//@param:       birth, at Date "出生日期,计算日期"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      Period "年龄" at在birth之前时为负数
+func DateAge(birth, at Date, policy LeapDayPolicy) Period {
+	if at.Before(birth) {
+		return DateAge(at, birth, policy).Negate()
+	}
+	months := (at.Year-birth.Year)*12 + at.Month - birth.Month
+	if birth.addMonthsPolicy(months, policy).After(at) {
+		months--
+	}
+	days := birth.addMonthsPolicy(months, policy).DaysUntil(at)
+	return Period{Years: months / 12, Months: months % 12, Days: int(days)}
+}
+
+//@description: 周岁, 2月29日出生时平年按policy满周岁
+//@param:       birth, at Date "出生日期,计算日期"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      int "周岁" at在birth之前时为负数
+func DateAgeYears(birth, at Date, policy LeapDayPolicy) int {
+	if at.Before(birth) {
+		return -DateAgeYears(at, birth, policy)
+	}
+	years := at.Year - birth.Year
+	if at.Before(birth.Anniversary(at.Year, policy)) {
+		years--
+	}
+	return years
+}
+
+//@description: 年龄(年,月,日), 按各自时区的日期计算
+//@param:       birth, at *DateTime "出生时间,计算时间"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      Period "年龄"
+func Age(birth, at *DateTime, policy LeapDayPolicy) Period {
+	return DateAge(birth.Date(), at.Date(), policy)
+}
+
+//@description: 周岁, 按各自时区的日期计算
+//@param:       birth, at *DateTime "出生时间,计算时间"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      int "周岁"
+func AgeYears(birth, at *DateTime, policy LeapDayPolicy) int {
+	return DateAgeYears(birth.Date(), at.Date(), policy)
+}
+
+//@description: from当天或之后的第一个周年日, 为from时区的0时
+//@param:       date *DateTime "纪念日 如: 生日, 注册时间"
+//@param:       from *DateTime "开始时间"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      *DateTime "周年日"
+func NextAnniversary(date, from *DateTime, policy LeapDayPolicy) *DateTime {
+	next := date.Date().NextAnniversary(from.Date(), policy)
+	return UnixToDateTime(next.Unix(from.zone), from.zone)
+}
+
+//@description: at当天是否是生日(周年日)
+//@param:       birth, at *DateTime "出生时间,判断时间"
+//@param:       policy LeapDayPolicy "2月29日在平年的处理方式"
+//@return:      bool "是否是生日"
+func IsBirthday(birth, at *DateTime, policy LeapDayPolicy) bool {
+	day := at.Date()
+	return !day.Before(birth.Date()) && birth.Date().Anniversary(day.Year, policy) == day
+}
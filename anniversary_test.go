@@ -0,0 +1,69 @@
+package datetime
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestDateAge(t *testing.T) {
+	leap := Date{Year: 2000, Month: 2, Day: 29}
+	cases := []struct {
+		birth, at Date
+		policy    LeapDayPolicy
+		want      Period
+	}{
+		{leap, Date{Year: 2001, Month: 2, Day: 28}, LeapDayFeb28, Period{Years: 1}},
+		{leap, Date{Year: 2001, Month: 2, Day: 28}, LeapDayMar1, Period{Months: 11, Days: 30}},
+		{leap, Date{Year: 2001, Month: 3, Day: 1}, LeapDayMar1, Period{Years: 1}},
+		{leap, Date{Year: 2001, Month: 3, Day: 1}, LeapDayFeb28, Period{Years: 1, Days: 1}},
+		{leap, Date{Year: 2001, Month: 3, Day: 28}, LeapDayMar1, Period{Years: 1, Days: 27}},
+		{leap, Date{Year: 2004, Month: 2, Day: 29}, LeapDayMar1, Period{Years: 4}},
+		{leap, Date{Year: 2003, Month: 2, Day: 28}, LeapDayMar1, Period{Years: 2, Months: 11, Days: 30}},
+		{Date{Year: 2020, Month: 1, Day: 31}, Date{Year: 2020, Month: 3, Day: 1}, LeapDayFeb28, Period{Months: 1, Days: 1}},
+		{Date{Year: 2000, Month: 5, Day: 20}, Date{Year: 2024, Month: 5, Day: 19}, LeapDayMar1, Period{Years: 23, Months: 11, Days: 29}},
+		{Date{Year: 2024, Month: 5, Day: 19}, Date{Year: 2000, Month: 5, Day: 20}, LeapDayFeb28, Period{Years: -23, Months: -11, Days: -29}},
+	}
+	for _, c := range cases {
+		if p := DateAge(c.birth, c.at, c.policy); p != c.want {
+			t.Errorf("DateAge(%v, %v, %v) = %+v, want %+v", c.birth, c.at, c.policy, p, c.want)
+		}
+	}
+	zone := Zones.E8
+	birth, _ := DateClockToDateTime(2020, 2, 29, 10, 0, 0, zone)
+	at, _ := DateClockToDateTime(2023, 2, 28, 23, 0, 0, zone)
+	if p := Age(birth, at, LeapDayMar1); p != (Period{Years: 2, Months: 11, Days: 30}) {
+		t.Errorf("Age = %+v, want P2Y11M30D", p)
+	}
+	if !IsBirthday(birth, at, LeapDayFeb28) || IsBirthday(birth, at, LeapDayMar1) {
+		t.Errorf("IsBirthday(%v) does not follow the policy", at.YmdHMS())
+	}
+}
+
+//年数与 DateAgeYears 一致, 且 LeapDayFeb28 时 birth.AddPeriod(age) == at
+func TestDateAgeYears(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 100000; i++ {
+		birth := DayNumberToDate(int64(r.Intn(40000)))
+		if i%4 == 0 {
+			birth = Date{Year: 1904 + 4*r.Intn(30), Month: 2, Day: 29}
+		}
+		at := DayNumberToDate(int64(r.Intn(40000)))
+		for _, policy := range []LeapDayPolicy{LeapDayFeb28, LeapDayMar1} {
+			p := DateAge(birth, at, policy)
+			if years := DateAgeYears(birth, at, policy); p.Years != years {
+				t.Fatalf("DateAge(%v, %v, %v) = %+v, DateAgeYears = %v", birth, at, policy, p, years)
+			}
+			if DateAge(at, birth, policy) != p.Negate() {
+				t.Fatalf("DateAge(%v, %v, %v) is not antisymmetric", birth, at, policy)
+			}
+			if p.Months < -11 || p.Months > 11 || p.Days < -31 || p.Days > 31 {
+				t.Fatalf("DateAge(%v, %v, %v) = %+v", birth, at, policy, p)
+			}
+			if policy == LeapDayFeb28 && !at.Before(birth) && birth.AddPeriod(p) != at {
+				t.Fatalf("%v + %+v != %v", birth, p, at)
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package datetime
+
+import (
+	"math"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//时间单位
+type Unit int
+
+const (
+	UnitMinute   Unit = iota + 1 //分
+	UnitHour                     //时
+	UnitDay                      //日
+	UnitWeek                     //周(星期1为一周的开始)
+	UnitMonth                    //月
+	UnitQuarter                  //季度
+	UnitHalfYear                 //半年
+	UnitYear                     //年
+)
+
+func (u Unit) String() string {
+	switch u {
+	case UnitMinute:
+		return "minute"
+	case UnitHour:
+		return "hour"
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitQuarter:
+		return "quarter"
+	case UnitHalfYear:
+		return "half-year"
+	case UnitYear:
+		return "year"
+	}
+	return "unknown"
+}
+
+//单位对应的月数, 非按月计算的单位返回0
+func (u Unit) months() int {
+	switch u {
+	case UnitMonth:
+		return 1
+	case UnitQuarter:
+		return 3
+	case UnitHalfYear:
+		return 6
+	case UnitYear:
+		return 12
+	}
+	return 0
+}
+
+//向下取整除法
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+//向下取整取模, 结果与b同号
+func floorMod(a, b int64) int64 {
+	return a - floorDiv(a, b)*b
+}
+
+//返回当地时间的天数(1970年1月1日以来, 向下取整)
+func localDayNumber(unix int64, zone TimeZone) int64 {
+	return floorDiv(unix+zone.Offset(), daySec)
+}
+
+//返回 年,月(可溢出) 的1日0时的秒级时间戳
+func unixMonthStart(year, month int, zone TimeZone) int64 {
+	year += int(floorDiv(int64(month-1), 12))
+	month = int(int64(month-1)-floorDiv(int64(month-1), 12)*12) + 1
+	unix, _, _, _ := DateClockToUnix(year, month, 1, 0, 0, 0, zone)
+	return unix
+}
+
+//@description: 返回时间戳所在周的开始(0时)的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       firstDay int "一周的第一天(1-7)" 如: 1星期1为一周的开始, 7星期天为一周的开始
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func UnixStartOfWeek(unix int64, firstDay int, zone TimeZone) (int64, error) {
+	if firstDay < 1 || firstDay > 7 {
+		return 0, newRangeError("week", int64(firstDay), 1, 7)
+	}
+	days := (UnixWeekdayA(unix, zone) - firstDay + 7) % 7
+	return UnixDayZeroHour(unix, zone) - int64(days)*daySec, nil
+}
+
+//@description: 返回时间戳所在周的最后一秒的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       firstDay int "一周的第一天(1-7)"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func UnixEndOfWeek(unix int64, firstDay int, zone TimeZone) (int64, error) {
+	start, err := UnixStartOfWeek(unix, firstDay, zone)
+	if err != nil {
+		return 0, err
+	}
+	return start + weekSec - 1, nil
+}
+
+//@description: 返回时间戳所在时间单位的开始的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       unit Unit "时间单位" 周以星期1为开始, 其它开始日使用 UnixStartOfWeek
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func UnixStartOf(unix int64, unit Unit, zone TimeZone) (int64, error) {
+	return UnixTruncate(unix, unit, 1, zone)
+}
+
+//@description: 返回时间戳所在时间单位的最后一秒的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       unit Unit "时间单位"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func UnixEndOf(unix int64, unit Unit, zone TimeZone) (int64, error) {
+	start, err := UnixTruncate(unix, unit, 1, zone)
+	if err != nil {
+		return 0, err
+	}
+	switch unit {
+	case UnitMinute:
+		return start + minSec - 1, nil
+	case UnitHour:
+		return start + hourSec - 1, nil
+	case UnitDay:
+		return start + daySec - 1, nil
+	case UnitWeek:
+		return start + weekSec - 1, nil
+	}
+	year, month, _, _, _, _, _, _ := UnixToDateClock(start, zone)
+	return unixMonthStart(year, month+unit.months(), zone) - 1, nil
+}
+
+//@description: 时间戳按N个时间单位向下取整, 以当地时间对齐
+//              分,时: 对齐到当天0时起的N分钟,N小时
+//              日,周: 对齐到1970年1月1日起的N天,N周
+//              月,季度,半年,年: 对齐到公元0年1月起的N个单位
+//@param:       unix int64 "秒级时间戳"
+//@param:       unit Unit "时间单位"
+//@param:       n int "单位个数(>0)"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func UnixTruncate(unix int64, unit Unit, n int, zone TimeZone) (int64, error) {
+	if n < 1 {
+		return 0, newRangeError("n", int64(n), 1, math.MaxInt)
+	}
+	offset := zone.Offset()
+	unixLocal := unix + offset
+	switch unit {
+	case UnitMinute, UnitHour:
+		size := int64(n) * minSec
+		if unit == UnitHour {
+			size = int64(n) * hourSec
+		}
+		dayStart := floorDiv(unixLocal, daySec) * daySec
+		return dayStart + floorDiv(unixLocal-dayStart, size)*size - offset, nil
+	case UnitDay:
+		days := localDayNumber(unix, zone)
+		return floorDiv(days, int64(n))*int64(n)*daySec - offset, nil
+	case UnitWeek:
+		start, _ := UnixStartOfWeek(unix, 1, zone)
+		//1970年1月5日为星期1
+		weeks := floorDiv(localDayNumber(start, zone)-4, 7)
+		weeks = floorDiv(weeks, int64(n)) * int64(n)
+		return (weeks*7+4)*daySec - offset, nil
+	case UnitMonth, UnitQuarter, UnitHalfYear, UnitYear:
+		year, month, _, _, _, _, _, _ := UnixToDateClock(unix, zone)
+		size := int64(unit.months() * n)
+		months := int64(year)*12 + int64(month-1)
+		months = floorDiv(months, size) * size
+		return unixMonthStart(0, int(months)+1, zone), nil
+	}
+	return 0, newRangeError("unit", int64(unit), int64(UnitMinute), int64(UnitYear))
+}
+
+//@description: 返回所在时间单位的开始的秒级时间戳
+//@param:       unit Unit "时间单位"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) StartOf(unit Unit) (int64, error) {
+	return UnixStartOf(my.unix, unit, my.zone)
+}
+
+//@description: 返回所在时间单位的最后一秒的秒级时间戳
+//@param:       unit Unit "时间单位"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) EndOf(unit Unit) (int64, error) {
+	return UnixEndOf(my.unix, unit, my.zone)
+}
+
+//@description: 按N个时间单位向下取整的秒级时间戳
+//@param:       unit Unit "时间单位"
+//@param:       n int "单位个数(>0)"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) Truncate(unit Unit, n int) (int64, error) {
+	return UnixTruncate(my.unix, unit, n, my.zone)
+}
+
+//@description: 返回所在周的开始(0时)的秒级时间戳
+//@param:       firstDay int "一周的第一天(1-7)"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) StartOfWeek(firstDay int) (int64, error) {
+	return UnixStartOfWeek(my.unix, firstDay, my.zone)
+}
+
+//@description: 返回所在周的最后一秒的秒级时间戳
+//@param:       firstDay int "一周的第一天(1-7)"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) EndOfWeek(firstDay int) (int64, error) {
+	return UnixEndOfWeek(my.unix, firstDay, my.zone)
+}
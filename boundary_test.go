@@ -0,0 +1,119 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//2020-08-15 14:37:25 星期六(北京时间)
+const boundaryRef = 1597473445
+
+func cstString(unix int64) string {
+	return time.Unix(unix, 0).In(time.FixedZone("CST", 8*hourSec)).Format("2006-01-02 15:04:05")
+}
+
+func TestUnixStartOfEndOf(t *testing.T) {
+	cases := []struct {
+		unit       Unit
+		start, end string
+	}{
+		{UnitMinute, "2020-08-15 14:37:00", "2020-08-15 14:37:59"},
+		{UnitHour, "2020-08-15 14:00:00", "2020-08-15 14:59:59"},
+		{UnitDay, "2020-08-15 00:00:00", "2020-08-15 23:59:59"},
+		{UnitWeek, "2020-08-10 00:00:00", "2020-08-16 23:59:59"},
+		{UnitMonth, "2020-08-01 00:00:00", "2020-08-31 23:59:59"},
+		{UnitQuarter, "2020-07-01 00:00:00", "2020-09-30 23:59:59"},
+		{UnitHalfYear, "2020-07-01 00:00:00", "2020-12-31 23:59:59"},
+		{UnitYear, "2020-01-01 00:00:00", "2020-12-31 23:59:59"},
+	}
+	for _, c := range cases {
+		start, err := UnixStartOf(boundaryRef, c.unit, Zones.E8)
+		if err != nil || cstString(start) != c.start {
+			t.Errorf("UnixStartOf(%v) = %v, %v, want %v", c.unit, cstString(start), err, c.start)
+		}
+		end, err := UnixEndOf(boundaryRef, c.unit, Zones.E8)
+		if err != nil || cstString(end) != c.end {
+			t.Errorf("UnixEndOf(%v) = %v, %v, want %v", c.unit, cstString(end), err, c.end)
+		}
+		//开始和结束本身也在同一个单位内
+		if s, _ := UnixStartOf(end, c.unit, Zones.E8); s != start {
+			t.Errorf("UnixStartOf(UnixEndOf(%v)) = %v, want %v", c.unit, cstString(s), c.start)
+		}
+	}
+}
+
+func TestUnixTruncate(t *testing.T) {
+	cases := []struct {
+		unit Unit
+		n    int
+		want string
+	}{
+		{UnitMinute, 15, "2020-08-15 14:30:00"},
+		{UnitHour, 6, "2020-08-15 12:00:00"},
+		{UnitHour, 5, "2020-08-15 10:00:00"},
+		{UnitDay, 7, "2020-08-13 00:00:00"},
+		{UnitWeek, 2, "2020-08-10 00:00:00"},
+		{UnitWeek, 7, "2020-08-03 00:00:00"},
+		{UnitMonth, 2, "2020-07-01 00:00:00"},
+		{UnitMonth, 5, "2020-06-01 00:00:00"},
+		{UnitQuarter, 2, "2020-07-01 00:00:00"},
+		{UnitYear, 10, "2020-01-01 00:00:00"},
+		{UnitYear, 3, "2019-01-01 00:00:00"},
+	}
+	for _, c := range cases {
+		unix, err := UnixTruncate(boundaryRef, c.unit, c.n, Zones.E8)
+		if err != nil || cstString(unix) != c.want {
+			t.Errorf("UnixTruncate(%v, %v) = %v, %v, want %v", c.unit, c.n, cstString(unix), err, c.want)
+		}
+	}
+	for _, c := range []struct {
+		unit Unit
+		n    int
+	}{{UnitDay, 0}, {UnitDay, -1}, {Unit(0), 1}, {UnitYear + 1, 1}} {
+		if _, err := UnixTruncate(boundaryRef, c.unit, c.n, Zones.E8); !errors.Is(err, ErrRange) {
+			t.Errorf("UnixTruncate(%v, %v) error = %v, want ErrRange", c.unit, c.n, err)
+		}
+	}
+}
+
+func TestUnixStartOfWeek(t *testing.T) {
+	cases := []struct {
+		firstDay   int
+		start, end string
+	}{
+		{1, "2020-08-10 00:00:00", "2020-08-16 23:59:59"},
+		{6, "2020-08-15 00:00:00", "2020-08-21 23:59:59"},
+		{7, "2020-08-09 00:00:00", "2020-08-15 23:59:59"},
+	}
+	for _, c := range cases {
+		start, err := UnixStartOfWeek(boundaryRef, c.firstDay, Zones.E8)
+		if err != nil || cstString(start) != c.start {
+			t.Errorf("UnixStartOfWeek(%v) = %v, %v, want %v", c.firstDay, cstString(start), err, c.start)
+		}
+		end, err := UnixEndOfWeek(boundaryRef, c.firstDay, Zones.E8)
+		if err != nil || cstString(end) != c.end {
+			t.Errorf("UnixEndOfWeek(%v) = %v, %v, want %v", c.firstDay, cstString(end), err, c.end)
+		}
+	}
+	for _, firstDay := range []int{0, 8} {
+		if _, err := UnixStartOfWeek(boundaryRef, firstDay, Zones.E8); !errors.Is(err, ErrRange) {
+			t.Errorf("UnixStartOfWeek(%v) error = %v, want ErrRange", firstDay, err)
+		}
+	}
+}
+
+func TestUnixHourZeroMin(t *testing.T) {
+	//按UTC的整小时对齐
+	cases := []struct{ unix, want int64 }{{0, 0}, {3599, 0}, {3600, 3600}, {5400, 3600}, {-1, -3600}, {-3600, -3600}, {-3601, -7200}}
+	for _, c := range cases {
+		if got := UnixHourZeroMin(c.unix); got != c.want {
+			t.Errorf("UnixHourZeroMin(%v) = %v, want %v", c.unix, got, c.want)
+		}
+	}
+	if got := UnixHourZeroMinZone(boundaryRef, Zones.E8); cstString(got) != "2020-08-15 14:00:00" {
+		t.Errorf("UnixHourZeroMinZone = %v", cstString(got))
+	}
+}
@@ -0,0 +1,359 @@
+package datetime
+
+import (
+	"sync"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//节假日规则
+type HolidayRule interface {
+	//返回该年中的节假日(1970年1月1日以来的天数)
+	Days(year int) []int64
+}
+
+type fixedHoliday struct {
+	month int
+	day   int
+}
+
+func (my fixedHoliday) Days(year int) []int64 {
+	if my.month == 2 && my.day == 29 && !leapYear(year) {
+		return nil
+	}
+	return []int64{dateDayNumber(year, my.month, my.day)}
+}
+
+type nthWeekdayHoliday struct {
+	month   int
+	n       int
+	weekday int
+}
+
+func (my nthWeekdayHoliday) Days(year int) []int64 {
+	if my.n > 0 {
+		first := dateDayNumber(year, my.month, 1)
+		diff := (my.weekday - dayNumberWeekdayA(first) + 7) % 7
+		days := first + int64(diff+(my.n-1)*7)
+		if _, month, _ := dayNumberDate(days); month != my.month {
+			return nil
+		}
+		return []int64{days}
+	}
+	nextYear, nextMonth := year, my.month+1
+	if nextMonth > 12 {
+		nextYear, nextMonth = year+1, 1
+	}
+	last := dateDayNumber(nextYear, nextMonth, 1) - 1
+	diff := (dayNumberWeekdayA(last) - my.weekday + 7) % 7
+	days := last - int64(diff+(-my.n-1)*7)
+	if _, month, _ := dayNumberDate(days); month != my.month {
+		return nil
+	}
+	return []int64{days}
+}
+
+type easterHoliday struct {
+	offset int
+}
+
+func (my easterHoliday) Days(year int) []int64 {
+	month, day := easter(year)
+	return []int64{dateDayNumber(year, month, day) + int64(my.offset)}
+}
+
+//复活节日期(公历, Anonymous Gregorian algorithm), 使用向下取整除法以支持公元前的年份
+func easter(year int) (month, day int) {
+	y := int64(year)
+	a := floorMod(y, 19)
+	b := floorDiv(y, 100)
+	c := floorMod(y, 100)
+	d := floorDiv(b, 4)
+	e := floorMod(b, 4)
+	f := floorDiv(b+8, 25)
+	g := floorDiv(b-f+1, 3)
+	h := floorMod(19*a+b-d-g+15, 30)
+	i := c / 4
+	k := c % 4
+	l := floorMod(32+2*e+2*i-h-k, 7)
+	m := (a + 11*h + 22*l) / 451
+	month = int((h + l - 7*m + 114) / 31)
+	day = int((h+l-7*m+114)%31) + 1
+	return
+}
+
+//@description: 每年固定日期的节假日规则
+//@param:       month, day int "月,日"
+//@return:      HolidayRule "节假日规则"
+//@return:      error "错误信息"
+func FixedHoliday(month, day int) (HolidayRule, error) {
+	if err := checkDateClock(2000, month, day, 0, 0, 0); err != nil {
+		return nil, err
+	}
+	return fixedHoliday{month: month, day: day}, nil
+}
+
+//@description: 每年某月第N个星期几的节假日规则 如: 11月第4个星期4
+//@param:       month int "月(1-12)"
+//@param:       n int "第几个(1-5)" 负数从月末倒数, -1为最后一个
+//@param:       weekday int "星期(1-7)"
+//@return:      HolidayRule "节假日规则"
+//@return:      error "错误信息"
+func NthWeekdayHoliday(month, n, weekday int) (HolidayRule, error) {
+	if month < 1 || month > 12 {
+		return nil, newRangeError("month", int64(month), 1, 12)
+	}
+	if n == 0 || n > 5 || n < -5 {
+		return nil, newRangeError("n", int64(n), -5, 5)
+	}
+	if weekday < 1 || weekday > 7 {
+		return nil, newRangeError("week", int64(weekday), 1, 7)
+	}
+	return nthWeekdayHoliday{month: month, n: n, weekday: weekday}, nil
+}
+
+//@description: 相对复活节的节假日规则 如: -2耶稣受难日, 1复活节星期一
+//@param:       offset int "相对复活节的天数"
+//@return:      HolidayRule "节假日规则"
+func EasterHoliday(offset int) HolidayRule {
+	return easterHoliday{offset: offset}
+}
+
+//工作日日历
+//查询方法可并发调用; SetWeekend, AddHoliday, AddWorkday, AddHolidayRule 不能与其它方法并发调用
+type BusinessCalendar struct {
+	weekend  [8]bool        //周末, 下标为星期(1-7)
+	holidays map[int64]bool //节假日(1970年1月1日以来的天数)
+	workdays map[int64]bool //调休工作日(1970年1月1日以来的天数)
+	rules    []HolidayRule  //节假日规则
+
+	mu       sync.Mutex             //保护 ruleDays
+	ruleDays map[int]map[int64]bool //按年缓存的规则节假日, 键为规则的年份
+}
+
+//连续多少天没有工作日时放弃查找
+const businessSearchDays = 366
+
+//@description: 创建工作日日历, 默认星期6和星期天为周末
+//@return:      *BusinessCalendar "工作日日历"
+func NewBusinessCalendar() *BusinessCalendar {
+	cal := &BusinessCalendar{holidays: map[int64]bool{}, workdays: map[int64]bool{}}
+	cal.weekend[6] = true
+	cal.weekend[7] = true
+	return cal
+}
+
+//@description: 设置周末
+//@param:       weeks ...int "星期(1-7)"
+//@return:      error "错误信息" 7天都是周末时返回错误
+func (my *BusinessCalendar) SetWeekend(weeks ...int) error {
+	var weekend [8]bool
+	n := 0
+	for _, week := range weeks {
+		if week < 1 || week > 7 {
+			return newRangeError("week", int64(week), 1, 7)
+		}
+		if !weekend[week] {
+			weekend[week] = true
+			n++
+		}
+	}
+	if n == 7 {
+		return newRangeError("weekend", int64(n), 0, 6)
+	}
+	my.weekend = weekend
+	return nil
+}
+
+//@description: 添加节假日
+//@param:       year, month, day int "年,月,日"
+//@return:      error "错误信息"
+func (my *BusinessCalendar) AddHoliday(year, month, day int) error {
+	if err := checkDateClock(year, month, day, 0, 0, 0); err != nil {
+		return err
+	}
+	my.holidays[dateDayNumber(year, month, day)] = true
+	return nil
+}
+
+//@description: 添加调休工作日, 调休工作日即使是周末或节假日也上班
+//@param:       year, month, day int "年,月,日"
+//@return:      error "错误信息"
+func (my *BusinessCalendar) AddWorkday(year, month, day int) error {
+	if err := checkDateClock(year, month, day, 0, 0, 0); err != nil {
+		return err
+	}
+	my.workdays[dateDayNumber(year, month, day)] = true
+	return nil
+}
+
+//@description: 添加节假日规则
+//@param:       rule HolidayRule "节假日规则"
+func (my *BusinessCalendar) AddHolidayRule(rule HolidayRule) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+	my.rules = append(my.rules, rule)
+	my.ruleDays = nil
+}
+
+//规则在year年的节假日, 计算后缓存
+func (my *BusinessCalendar) yearRuleDays(year int) map[int64]bool {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+	days, ok := my.ruleDays[year]
+	if !ok {
+		days = map[int64]bool{}
+		for _, rule := range my.rules {
+			for _, d := range rule.Days(year) {
+				days[d] = true
+			}
+		}
+		if my.ruleDays == nil {
+			my.ruleDays = map[int]map[int64]bool{}
+		}
+		my.ruleDays[year] = days
+	}
+	return days
+}
+
+//1970年1月1日以来的第N天是否是节假日
+func (my *BusinessCalendar) isHoliday(days int64) bool {
+	if my.holidays[days] {
+		return true
+	}
+	if len(my.rules) == 0 {
+		return false
+	}
+	year, _, _ := dayNumberDate(days)
+	//规则的节假日可能因偏移落在相邻年份
+	for y := year - 1; y <= year+1; y++ {
+		if my.yearRuleDays(y)[days] {
+			return true
+		}
+	}
+	return false
+}
+
+//1970年1月1日以来的第N天是否是工作日
+func (my *BusinessCalendar) isBusinessDay(days int64) bool {
+	if my.workdays[days] {
+		return true
+	}
+	if my.weekend[dayNumberWeekdayA(days)] {
+		return false
+	}
+	return !my.isHoliday(days)
+}
+
+//从第N天起向前或向后找第n个工作日, n为0时返回N, 连续 businessSearchDays 天没有工作日时返回错误
+func (my *BusinessCalendar) addBusinessDays(days int64, n int) (int64, error) {
+	step := int64(1)
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+	for gap := 0; n > 0; {
+		days += step
+		if my.isBusinessDay(days) {
+			n--
+			gap = 0
+		} else if gap++; gap > businessSearchDays {
+			return 0, newRangeError("gap", int64(gap), 0, businessSearchDays)
+		}
+	}
+	return days, nil
+}
+
+//@description: 时间戳所在日是否是工作日
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      bool "是否是工作日"
+func (my *BusinessCalendar) IsBusinessDay(unix int64, zone TimeZone) bool {
+	return my.isBusinessDay(localDayNumber(unix, zone))
+}
+
+//@description: 返回N个工作日后同一时刻的秒级时间戳 如: T+3
+//@param:       unix int64 "秒级时间戳"
+//@param:       n int "工作日数" 负数为N个工作日前
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息" 一年以上没有工作日时返回错误
+func (my *BusinessCalendar) AddBusinessDays(unix int64, n int, zone TimeZone) (int64, error) {
+	days := localDayNumber(unix, zone)
+	target, err := my.addBusinessDays(days, n)
+	if err != nil {
+		return 0, err
+	}
+	return unix + (target-days)*daySec, nil
+}
+
+//@description: 返回下一个工作日0时的秒级时间戳(不含当天)
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息" 一年以上没有工作日时返回错误
+func (my *BusinessCalendar) NextBusinessDay(unix int64, zone TimeZone) (int64, error) {
+	days, err := my.addBusinessDays(localDayNumber(unix, zone), 1)
+	if err != nil {
+		return 0, err
+	}
+	return days*daySec - zone.Offset(), nil
+}
+
+//@description: 返回两个时间戳之间的工作日数, 不含from所在日, 含to所在日(to早于from时也是)
+//              与 AddBusinessDays 互逆: BusinessDaysBetween(from, AddBusinessDays(from, n)) == n, n可为负数
+//@param:       from, to int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int "工作日数" to早于from时为负数
+func (my *BusinessCalendar) BusinessDaysBetween(from, to int64, zone TimeZone) int {
+	start, end := localDayNumber(from, zone), localDayNumber(to, zone)
+	if end < start {
+		//[to, from)
+		return -my.countBusinessDays(end, start-1)
+	}
+	//(from, to]
+	return my.countBusinessDays(start+1, end)
+}
+
+//第first到last天(含)的工作日数
+func (my *BusinessCalendar) countBusinessDays(first, last int64) int {
+	var n int
+	for days := first; days <= last; days++ {
+		if my.isBusinessDay(days) {
+			n++
+		}
+	}
+	return n
+}
+
+//@description: 是否是工作日
+//@param:       cal *BusinessCalendar "工作日日历"
+//@return:      bool "是否是工作日"
+func (my *DateTime) IsBusinessDay(cal *BusinessCalendar) bool {
+	return cal.IsBusinessDay(my.unix, my.zone)
+}
+
+//@description: 返回N个工作日后同一时刻的秒级时间戳
+//@param:       cal *BusinessCalendar "工作日日历"
+//@param:       n int "工作日数" 负数为N个工作日前
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) AddBusinessDays(cal *BusinessCalendar, n int) (int64, error) {
+	return cal.AddBusinessDays(my.unix, n, my.zone)
+}
+
+//@description: 返回下一个工作日0时的秒级时间戳(不含当天)
+//@param:       cal *BusinessCalendar "工作日日历"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *DateTime) NextBusinessDay(cal *BusinessCalendar) (int64, error) {
+	return cal.NextBusinessDay(my.unix, my.zone)
+}
+
+//@description: 返回到to之间的工作日数, 不含当天, 含to所在日
+//@param:       cal *BusinessCalendar "工作日日历"
+//@param:       to int64 "秒级时间戳"
+//@return:      int "工作日数"
+func (my *DateTime) BusinessDaysBetween(cal *BusinessCalendar, to int64) int {
+	return cal.BusinessDaysBetween(my.unix, to, my.zone)
+}
@@ -0,0 +1,191 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestEaster(t *testing.T) {
+	cases := []struct {
+		year, month, day int
+	}{
+		{1583, 4, 10},
+		{1818, 3, 22},
+		{1943, 4, 25},
+		{2000, 4, 23},
+		{2008, 3, 23},
+		{2019, 4, 21},
+		{2024, 3, 31},
+		{2025, 4, 20},
+		{2038, 4, 25},
+	}
+	for _, c := range cases {
+		if month, day := easter(c.year); month != c.month || day != c.day {
+			t.Errorf("easter(%v) = %v-%v, want %v-%v", c.year, month, day, c.month, c.day)
+		}
+	}
+	//公历复活节以5700000年为周期, 公元前的年份与周期后的年份相同
+	for year := -3000; year <= 3000; year++ {
+		month, day := easter(year)
+		if m, d := easter(year + 5700000); m != month || d != day {
+			t.Fatalf("easter(%v) = %v-%v, easter(%v) = %v-%v", year, month, day, year+5700000, m, d)
+		}
+		if month == 3 && day < 22 || month == 4 && day > 25 || month < 3 || month > 4 {
+			t.Fatalf("easter(%v) = %v-%v, want between 03-22 and 04-25", year, month, day)
+		}
+		if weekday := dayNumberWeekdayA(dateDayNumber(year, month, day)); weekday != 7 {
+			t.Fatalf("easter(%v) = %v-%v is weekday %v, want sunday", year, month, day, weekday)
+		}
+	}
+}
+
+func TestHolidayRule(t *testing.T) {
+	thanksgiving, _ := NthWeekdayHoliday(11, 4, 4)
+	memorial, _ := NthWeekdayHoliday(5, -1, 1)
+	fifthMonday, _ := NthWeekdayHoliday(2, 5, 1)
+	leapDay, _ := FixedHoliday(2, 29)
+	cases := []struct {
+		rule HolidayRule
+		year int
+		want []string
+	}{
+		{thanksgiving, 2024, []string{"2024-11-28"}},
+		{thanksgiving, 2025, []string{"2025-11-27"}},
+		{memorial, 2024, []string{"2024-05-27"}},
+		{memorial, 2021, []string{"2021-05-31"}},
+		{fifthMonday, 2021, nil},
+		{leapDay, 2023, nil},
+		{leapDay, 2024, []string{"2024-02-29"}},
+		{EasterHoliday(-2), 2024, []string{"2024-03-29"}},
+		{EasterHoliday(1), 2025, []string{"2025-04-21"}},
+	}
+	for _, c := range cases {
+		days := c.rule.Days(c.year)
+		var got []string
+		for _, d := range days {
+			got = append(got, time.Unix(d*daySec, 0).UTC().Format("2006-01-02"))
+		}
+		if len(got) != len(c.want) || len(got) > 0 && got[0] != c.want[0] {
+			t.Errorf("%#v.Days(%v) = %v, want %v", c.rule, c.year, got, c.want)
+		}
+	}
+	if _, err := NthWeekdayHoliday(11, 6, 4); !errors.Is(err, ErrRange) {
+		t.Errorf("NthWeekdayHoliday(n=6) error = %v, want ErrRange", err)
+	}
+	if _, err := FixedHoliday(2, 30); !errors.Is(err, ErrRange) {
+		t.Errorf("FixedHoliday(2, 30) error = %v, want ErrRange", err)
+	}
+}
+
+//2020年国庆节: 10月1日至8日放假, 9月27日(星期天)和10月10日(星期6)调休上班
+func chinaCalendar(t *testing.T) *BusinessCalendar {
+	cal := NewBusinessCalendar()
+	for day := 1; day <= 8; day++ {
+		if err := cal.AddHoliday(2020, 10, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cal.AddWorkday(2020, 9, 27); err != nil {
+		t.Fatal(err)
+	}
+	if err := cal.AddWorkday(2020, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := chinaCalendar(t)
+	cases := []struct {
+		from string
+		n    int
+		want string
+	}{
+		{"2020-09-25 10:00:00", 0, "2020-09-25 10:00:00"},
+		{"2020-09-25 10:00:00", 1, "2020-09-27 10:00:00"},
+		{"2020-09-25 10:00:00", 4, "2020-09-30 10:00:00"},
+		{"2020-09-25 10:00:00", 5, "2020-10-09 10:00:00"},
+		{"2020-09-25 10:00:00", 6, "2020-10-10 10:00:00"},
+		{"2020-09-25 10:00:00", 7, "2020-10-12 10:00:00"},
+		{"2020-10-12 10:00:00", -1, "2020-10-10 10:00:00"},
+		{"2020-10-12 10:00:00", -3, "2020-09-30 10:00:00"},
+		{"2020-10-05 10:00:00", 1, "2020-10-09 10:00:00"},
+		{"2020-10-05 10:00:00", -1, "2020-09-30 10:00:00"},
+	}
+	for _, c := range cases {
+		from, err := FormatToUnix(c.from, "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := cal.AddBusinessDays(from, c.n, Zones.E8)
+		if err != nil || cstString(got) != c.want {
+			t.Errorf("AddBusinessDays(%v, %v) = %v, %v, want %v", c.from, c.n, cstString(got), err, c.want)
+			continue
+		}
+		if n := cal.BusinessDaysBetween(from, got, Zones.E8); n != c.n {
+			t.Errorf("BusinessDaysBetween(%v, %v) = %v, want %v", c.from, c.want, n, c.n)
+		}
+	}
+	from, _ := FormatToUnix("2020-09-30 18:00:00", "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+	if next, err := cal.NextBusinessDay(from, Zones.E8); err != nil || cstString(next) != "2020-10-09 00:00:00" {
+		t.Errorf("NextBusinessDay = %v, %v, want 2020-10-09 00:00:00", cstString(next), err)
+	}
+	if cal.IsBusinessDay(from+daySec, Zones.E8) {
+		t.Errorf("IsBusinessDay(2020-10-01) = true, want false")
+	}
+	if !cal.IsBusinessDay(from-3*daySec, Zones.E8) {
+		t.Errorf("IsBusinessDay(2020-09-27) = false, want true")
+	}
+}
+
+func TestBusinessCalendarRule(t *testing.T) {
+	cal := NewBusinessCalendar()
+	goodFriday := EasterHoliday(-2)
+	cal.AddHolidayRule(goodFriday)
+	//2024-03-28 星期4, 下一个工作日跳过耶稣受难日和周末
+	from, _ := FormatToUnix("2024-03-28 09:00:00", "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+	if got, err := cal.AddBusinessDays(from, 1, Zones.E8); err != nil || cstString(got) != "2024-04-01 09:00:00" {
+		t.Errorf("AddBusinessDays = %v, %v, want 2024-04-01 09:00:00", cstString(got), err)
+	}
+	//规则按年缓存, 添加新规则后重新计算
+	easterMonday := EasterHoliday(1)
+	cal.AddHolidayRule(easterMonday)
+	if got, err := cal.AddBusinessDays(from, 1, Zones.E8); err != nil || cstString(got) != "2024-04-02 09:00:00" {
+		t.Errorf("AddBusinessDays = %v, %v, want 2024-04-02 09:00:00", cstString(got), err)
+	}
+}
+
+func TestBusinessCalendarError(t *testing.T) {
+	cal := NewBusinessCalendar()
+	if err := cal.SetWeekend(1, 2, 3, 4, 5, 6, 7); !errors.Is(err, ErrRange) {
+		t.Errorf("SetWeekend(all) error = %v, want ErrRange", err)
+	}
+	if err := cal.SetWeekend(0); !errors.Is(err, ErrRange) {
+		t.Errorf("SetWeekend(0) error = %v, want ErrRange", err)
+	}
+	if err := cal.AddHoliday(2021, 2, 29); !errors.Is(err, ErrRange) {
+		t.Errorf("AddHoliday(2021-02-29) error = %v, want ErrRange", err)
+	}
+	//周末之外的每一天都是节假日
+	if err := cal.SetWeekend(5, 6, 7); err != nil {
+		t.Fatal(err)
+	}
+	for month := 1; month <= 12; month++ {
+		for n := 1; n <= 5; n++ {
+			for week := 1; week <= 4; week++ {
+				rule, _ := NthWeekdayHoliday(month, n, week)
+				cal.AddHolidayRule(rule)
+			}
+		}
+	}
+	from, _ := FormatToUnix("2020-06-01 00:00:00", "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+	if _, err := cal.AddBusinessDays(from, 1, Zones.E8); !errors.Is(err, ErrRange) {
+		t.Errorf("AddBusinessDays(no business day) error = %v, want ErrRange", err)
+	}
+	if _, err := cal.NextBusinessDay(from, Zones.E8); !errors.Is(err, ErrRange) {
+		t.Errorf("NextBusinessDay(no business day) error = %v, want ErrRange", err)
+	}
+}
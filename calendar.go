@@ -0,0 +1,465 @@
+package datetime
+
+import (
+	"math"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//历法, 以1970年1月1日以来的天数(与 UnixDayNumber 一致)与其它历法互相转换
+type Calendar interface {
+	//历法名称
+	Name() string
+	//年,月,日 -> 1970年1月1日以来的天数
+	ToDayNumber(year, month, day int) (int64, error)
+	//1970年1月1日以来的天数 -> 年,月,日
+	FromDayNumber(days int64) (year, month, day int, err error)
+	//一年的月数
+	MonthsInYear(year int) int
+	//一个月的天数
+	MonthDays(year, month int) int
+	//月份名称
+	MonthName(year, month int) string
+}
+
+//检查历法中的年,月,日
+func checkCalendarDate(cal Calendar, year, month, day int) error {
+	if month < 1 || month > cal.MonthsInYear(year) {
+		return newRangeError("month", int64(month), 1, int64(cal.MonthsInYear(year)))
+	}
+	if day < 1 || day > cal.MonthDays(year, month) {
+		return newRangeError("day", int64(day), 1, int64(cal.MonthDays(year, month)))
+	}
+	return nil
+}
+
+//公历(格里高利历)
+type gregorianCalendar struct{}
+
+var GregorianCalendar Calendar = gregorianCalendar{}
+
+func (gregorianCalendar) Name() string {
+	return "gregorian"
+}
+
+func (gregorianCalendar) ToDayNumber(year, month, day int) (int64, error) {
+	if err := checkDateClock(year, month, day, 0, 0, 0); err != nil {
+		return 0, err
+	}
+	return dateDayNumber(year, month, day), nil
+}
+
+func (gregorianCalendar) FromDayNumber(days int64) (year, month, day int, err error) {
+	year, month, day = dayNumberDate(days)
+	return
+}
+
+func (gregorianCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (gregorianCalendar) MonthDays(year, month int) int {
+	if month < 1 || month > 12 {
+		return 0
+	}
+	if leapYear(year) {
+		return leapMonth[month-1]
+	}
+	return norMonth[month-1]
+}
+
+func (gregorianCalendar) MonthName(year, month int) string {
+	if month < 1 || month > 12 {
+		return ""
+	}
+	return monthNames[month-1]
+}
+
+//伊斯兰历(回历), 表格历法: 30年11闰, 历元为公元622年7月16日(儒略历)
+type islamicCalendar struct{}
+
+var IslamicCalendar Calendar = islamicCalendar{}
+
+//伊斯兰历元年1月1日距1970年1月1日的天数
+const islamicEpoch = -492148
+
+var islamicMonthNames = [12]string{"Muharram", "Safar", "Rabi' al-awwal", "Rabi' al-thani", "Jumada al-awwal", "Jumada al-thani", "Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qi'dah", "Dhu al-Hijjah"}
+
+func islamicLeapYear(year int) bool {
+	return ((14+11*year)%30+30)%30 < 11
+}
+
+func islamicDayNumber(year, month, day int) int64 {
+	y := int64(year)
+	return int64(day) + int64((59*(month-1)+1)/2) + (y-1)*354 + floorDiv(3+11*y, 30) + islamicEpoch - 1
+}
+
+func (islamicCalendar) Name() string {
+	return "islamic"
+}
+
+func (my islamicCalendar) ToDayNumber(year, month, day int) (int64, error) {
+	if err := checkCalendarDate(my, year, month, day); err != nil {
+		return 0, err
+	}
+	return islamicDayNumber(year, month, day), nil
+}
+
+func (islamicCalendar) FromDayNumber(days int64) (year, month, day int, err error) {
+	year = int(floorDiv(30*(days-islamicEpoch)+10646, 10631))
+	month = int(floorDiv(2*(days-islamicDayNumber(year, 1, 1)-29)+58, 59)) + 1
+	if month > 12 {
+		month = 12
+	}
+	if month < 1 {
+		month = 1
+	}
+	day = int(days-islamicDayNumber(year, month, 1)) + 1
+	return
+}
+
+func (islamicCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (islamicCalendar) MonthDays(year, month int) int {
+	if month < 1 || month > 12 {
+		return 0
+	}
+	if month%2 == 1 || (month == 12 && islamicLeapYear(year)) {
+		return 30
+	}
+	return 29
+}
+
+func (islamicCalendar) MonthName(year, month int) string {
+	if month < 1 || month > 12 {
+		return ""
+	}
+	return islamicMonthNames[month-1]
+}
+
+//希伯来历, 月份从尼散月(Nisan)开始为1, 提斯利月(Tishri)为7且为新年, 闰年的第13月为亚达月二(Adar II)
+type hebrewCalendar struct{}
+
+var HebrewCalendar Calendar = hebrewCalendar{}
+
+//希伯来历元(儒略日347995.5)距1970年1月1日的天数
+const hebrewEpoch = -2092592
+
+var hebrewMonthNames = [13]string{"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul", "Tishri", "Heshvan", "Kislev", "Teveth", "Shevat", "Adar", "Adar II"}
+
+func hebrewLeapYear(year int) bool {
+	return floorMod(int64(7*year+1), 19) < 7
+}
+
+//从历元到该年新年的天数(含新年推迟规则)
+func hebrewElapsedDays(year int) int64 {
+	months := floorDiv(235*int64(year)-234, 19)
+	parts := 12084 + 13753*months
+	days := months*29 + floorDiv(parts, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+func hebrewYearDelay(year int) int64 {
+	last, present, next := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+	if next-present == 356 {
+		return 2
+	}
+	if present-last == 382 {
+		return 1
+	}
+	return 0
+}
+
+//新年(提斯利月1日)距1970年1月1日的天数
+func hebrewNewYear(year int) int64 {
+	return hebrewEpoch + hebrewElapsedDays(year) + hebrewYearDelay(year) + 2
+}
+
+func hebrewYearDays(year int) int64 {
+	return hebrewNewYear(year+1) - hebrewNewYear(year)
+}
+
+func (hebrewCalendar) Name() string {
+	return "hebrew"
+}
+
+func (hebrewCalendar) MonthsInYear(year int) int {
+	if hebrewLeapYear(year) {
+		return 13
+	}
+	return 12
+}
+
+func (my hebrewCalendar) MonthDays(year, month int) int {
+	if month < 1 || month > my.MonthsInYear(year) {
+		return 0
+	}
+	switch month {
+	case 2, 4, 6, 10, 13:
+		return 29
+	case 12:
+		if !hebrewLeapYear(year) {
+			return 29
+		}
+	case 8:
+		if hebrewYearDays(year)%10 != 5 {
+			return 29
+		}
+	case 9:
+		if hebrewYearDays(year)%10 == 3 {
+			return 29
+		}
+	}
+	return 30
+}
+
+func (my hebrewCalendar) MonthName(year, month int) string {
+	if month < 1 || month > my.MonthsInYear(year) {
+		return ""
+	}
+	if month == 12 && hebrewLeapYear(year) {
+		return "Adar I"
+	}
+	return hebrewMonthNames[month-1]
+}
+
+func (my hebrewCalendar) dayNumber(year, month, day int) int64 {
+	days := hebrewNewYear(year) + int64(day) - 1
+	if month < 7 {
+		for m := 7; m <= my.MonthsInYear(year); m++ {
+			days += int64(my.MonthDays(year, m))
+		}
+		for m := 1; m < month; m++ {
+			days += int64(my.MonthDays(year, m))
+		}
+	} else {
+		for m := 7; m < month; m++ {
+			days += int64(my.MonthDays(year, m))
+		}
+	}
+	return days
+}
+
+func (my hebrewCalendar) ToDayNumber(year, month, day int) (int64, error) {
+	if year < 1 {
+		return 0, newRangeError("year", int64(year), 1, math.MaxInt)
+	}
+	if err := checkCalendarDate(my, year, month, day); err != nil {
+		return 0, err
+	}
+	return my.dayNumber(year, month, day), nil
+}
+
+func (my hebrewCalendar) FromDayNumber(days int64) (year, month, day int, err error) {
+	if days < hebrewNewYear(1) {
+		return 0, 0, 0, newRangeError("days", days, hebrewNewYear(1), math.MaxInt64)
+	}
+	year = int(floorDiv((days-hebrewEpoch)*98496, 35975351)) - 1
+	for days >= hebrewNewYear(year+1) {
+		year++
+	}
+	month = 7
+	if days >= my.dayNumber(year, 1, 1) {
+		month = 1
+	}
+	for days > my.dayNumber(year, month, my.MonthDays(year, month)) {
+		month++
+	}
+	day = int(days-my.dayNumber(year, month, 1)) + 1
+	return
+}
+
+//波斯历(伊朗太阳历), 使用 Borkowski 算法, 支持 -61 至 3177 年
+type persianCalendar struct{}
+
+var PersianCalendar Calendar = persianCalendar{}
+
+var persianBreaks = [...]int{-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210, 1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178}
+
+var persianMonthNames = [12]string{"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar", "Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"}
+
+//波斯历年的闰年信息
+//@return: leap int "距上一个闰年的年数(0为闰年)"
+//@return: gYear int "新年所在的公历年"
+//@return: march int "新年在公历3月的日期"
+func persianYear(year int) (leap, gYear, march int, err error) {
+	if year < persianBreaks[0] || year >= persianBreaks[len(persianBreaks)-1] {
+		return 0, 0, 0, newRangeError("year", int64(year), int64(persianBreaks[0]), int64(persianBreaks[len(persianBreaks)-1]-1))
+	}
+	gYear = year + 621
+	leapJ := -14
+	jp := persianBreaks[0]
+	var jump int
+	for i := 1; i < len(persianBreaks); i++ {
+		jm := persianBreaks[i]
+		jump = jm - jp
+		if year < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+	n := year - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gYear/4 - (gYear/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap = ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return
+}
+
+func (persianCalendar) Name() string {
+	return "persian"
+}
+
+func (persianCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (persianCalendar) MonthDays(year, month int) int {
+	switch {
+	case month < 1 || month > 12:
+		return 0
+	case month <= 6:
+		return 31
+	case month <= 11:
+		return 30
+	}
+	if leap, _, _, err := persianYear(year); err == nil && leap == 0 {
+		return 30
+	}
+	return 29
+}
+
+func (persianCalendar) MonthName(year, month int) string {
+	if month < 1 || month > 12 {
+		return ""
+	}
+	return persianMonthNames[month-1]
+}
+
+func (my persianCalendar) ToDayNumber(year, month, day int) (int64, error) {
+	_, gYear, march, err := persianYear(year)
+	if err != nil {
+		return 0, err
+	}
+	if err = checkCalendarDate(my, year, month, day); err != nil {
+		return 0, err
+	}
+	return dateDayNumber(gYear, 3, march) + int64((month-1)*31-month/7*(month-7)+day-1), nil
+}
+
+func (my persianCalendar) FromDayNumber(days int64) (year, month, day int, err error) {
+	gYear, _, _ := dayNumberDate(days)
+	year = gYear - 621
+	leap, _, march, err := persianYear(year)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	k := int(days - dateDayNumber(gYear, 3, march))
+	if k >= 0 {
+		if k <= 185 {
+			return year, 1 + k/31, k%31 + 1, nil
+		}
+		k -= 186
+	} else {
+		year--
+		k += 179
+		if leap == 1 {
+			k++
+		}
+	}
+	return year, 7 + k/30, k%30 + 1, nil
+}
+
+//@description: 秒级时间戳 -> 历法中的年,月,日
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@param:       cal Calendar "历法"
+//@return:      year, month, day int "年,月,日"
+//@return:      error "错误信息"
+func UnixToCalendar(unix int64, zone TimeZone, cal Calendar) (year, month, day int, err error) {
+	return cal.FromDayNumber(localDayNumber(unix, zone))
+}
+
+//@description: 历法中的年,月,日 -> 当天0时的秒级时间戳
+//@param:       cal Calendar "历法"
+//@param:       year, month, day int "年,月,日"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func CalendarToUnix(cal Calendar, year, month, day int, zone TimeZone) (int64, error) {
+	days, err := cal.ToDayNumber(year, month, day)
+	if err != nil {
+		return 0, err
+	}
+	return days*daySec - zone.Offset(), nil
+}
+
+//@description: 返回时间戳在历法中的格式化日期时间字符串, %Y %m %d %j 为历法中的年,月,日, %b %B 为历法中的月份名称
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@param:       cal Calendar "历法"
+//@param:       formatter string "格式化模板" 如: "%Y/%m/%d %H:%M:%S"
+//@return:      string "日期时间字符串"
+//@return:      error "错误信息"
+func UnixToCalendarFormat(unix int64, zone TimeZone, cal Calendar, formatter string) (string, error) {
+	year, month, day, err := UnixToCalendar(unix, zone, cal)
+	if err != nil {
+		return "", err
+	}
+	_, _, _, hour, min, sec, _, _ := UnixToDateClock(unix, zone)
+	return calendarFormat(cal, year, month, day, hour, min, sec, localDayNumber(unix, zone), zone.Offset(), formatter)
+}
+
+//历法中的年,月,日 -> 格式化, 星期由当天的天数计算, %b %B 为历法中的月份名称
+func calendarFormat(cal Calendar, year, month, day, hour, min, sec int, days, offset int64, formatter string) (string, error) {
+	//年的第一天, 希伯来历的1月(尼散月)不在年初
+	yearStart := days
+	for m := 1; m <= cal.MonthsInYear(year); m++ {
+		first, err := cal.ToDayNumber(year, m, 1)
+		if err != nil {
+			return "", err
+		}
+		if first < yearStart {
+			yearStart = first
+		}
+	}
+	fields := formatFields{year: year, month: month, day: day, hour: hour, min: min, sec: sec,
+		days: days, yDay: int(days-yearStart) + 1, offset: offset, cal: cal}
+	return fields.format(formatter, LocaleEnglish), nil
+}
+
+//@description: 返回历法中的年,月,日
+//@param:       cal Calendar "历法"
+//@return:      year, month, day int "年,月,日"
+//@return:      error "错误信息"
+func (my *DateTime) Calendar(cal Calendar) (year, month, day int, err error) {
+	return UnixToCalendar(my.unix, my.zone, cal)
+}
+
+//@description: 返回历法中的格式化日期时间字符串
+//@param:       cal Calendar "历法"
+//@param:       formatter string "格式化模板"
+//@return:      string "日期时间字符串"
+//@return:      error "错误信息"
+func (my *DateTime) CalendarFormat(cal Calendar, formatter string) (string, error) {
+	year, month, day, err := UnixToCalendar(my.unix, my.zone, cal)
+	if err != nil {
+		return "", err
+	}
+	return calendarFormat(cal, year, month, day, my.hour, my.min, my.sec, localDayNumber(my.unix, my.zone), my.zone.Offset(), formatter)
+}
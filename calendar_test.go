@@ -0,0 +1,174 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestCalendarConvert(t *testing.T) {
+	cases := []struct {
+		cal              Calendar
+		year, month, day int
+		date             string //公历
+	}{
+		{GregorianCalendar, 2020, 2, 29, "2020-02-29"},
+		{IslamicCalendar, 1, 1, 1, "0622-07-19"},
+		{IslamicCalendar, 1420, 9, 1, "1999-12-09"},
+		{IslamicCalendar, 1445, 1, 1, "2023-07-19"},
+		{HebrewCalendar, 5760, 7, 1, "1999-09-11"},
+		{HebrewCalendar, 5783, 7, 1, "2022-09-26"},
+		{HebrewCalendar, 5784, 7, 1, "2023-09-16"},
+		{HebrewCalendar, 5784, 1, 15, "2024-04-23"},
+		{HebrewCalendar, 5785, 7, 1, "2024-10-03"},
+		{PersianCalendar, 1, 1, 1, "0622-03-22"},
+		{PersianCalendar, 1399, 1, 1, "2020-03-20"},
+		{PersianCalendar, 1402, 1, 1, "2023-03-21"},
+		{PersianCalendar, 1403, 1, 1, "2024-03-20"},
+		{PersianCalendar, 1403, 12, 30, "2025-03-20"},
+		{PersianCalendar, 1404, 1, 1, "2025-03-21"},
+	}
+	for _, c := range cases {
+		days, err := c.cal.ToDayNumber(c.year, c.month, c.day)
+		if err != nil || holidayDate(days) != c.date {
+			t.Errorf("%v ToDayNumber(%v-%v-%v) = %v, %v, want %v", c.cal.Name(), c.year, c.month, c.day, holidayDate(days), err, c.date)
+			continue
+		}
+		year, month, day, err := c.cal.FromDayNumber(days)
+		if err != nil || year != c.year || month != c.month || day != c.day {
+			t.Errorf("%v FromDayNumber(%v) = %v-%v-%v, %v", c.cal.Name(), c.date, year, month, day, err)
+		}
+	}
+}
+
+func TestCalendarRoundTrip(t *testing.T) {
+	start := dateDayNumber(1900, 1, 1)
+	end := dateDayNumber(2100, 12, 31)
+	for _, cal := range []Calendar{GregorianCalendar, IslamicCalendar, HebrewCalendar, PersianCalendar} {
+		py, pm, pd, _ := cal.FromDayNumber(start - 1)
+		for days := start; days <= end; days++ {
+			year, month, day, err := cal.FromDayNumber(days)
+			if err != nil {
+				t.Fatalf("%v FromDayNumber(%v) error: %v", cal.Name(), days, err)
+			}
+			if back, err := cal.ToDayNumber(year, month, day); err != nil || back != days {
+				t.Fatalf("%v ToDayNumber(%v-%v-%v) = %v, %v, want %v", cal.Name(), year, month, day, back, err, days)
+			}
+			//相邻两天: 日数加1, 或上个月最后一天之后的1日
+			if day != 1 {
+				if year != py || month != pm || day != pd+1 {
+					t.Fatalf("%v %v-%v-%v follows %v-%v-%v", cal.Name(), year, month, day, py, pm, pd)
+				}
+			} else if pd != cal.MonthDays(py, pm) {
+				t.Fatalf("%v %v-%v-%v follows %v-%v-%v, month days %v", cal.Name(), year, month, day, py, pm, pd, cal.MonthDays(py, pm))
+			}
+			py, pm, pd = year, month, day
+		}
+	}
+}
+
+func TestCalendarYear(t *testing.T) {
+	//伊斯兰历: 每30年中第2,5,7,10,13,16,18,21,24,26,29年为闰年
+	leaps := map[int]bool{2: true, 5: true, 7: true, 10: true, 13: true, 16: true, 18: true, 21: true, 24: true, 26: true, 29: true}
+	for year := 1381; year <= 1500; year++ {
+		first, _ := IslamicCalendar.ToDayNumber(year, 1, 1)
+		next, _ := IslamicCalendar.ToDayNumber(year+1, 1, 1)
+		want := int64(354)
+		if leaps[(year-1)%30+1] {
+			want = 355
+		}
+		if next-first != want {
+			t.Errorf("islamic year %v has %v days, want %v", year, next-first, want)
+		}
+	}
+	//希伯来历: 19年中第3,6,8,11,14,17,19年为闰年
+	for year := 5700; year <= 5900; year++ {
+		leap := map[int]bool{3: true, 6: true, 8: true, 11: true, 14: true, 17: true, 19: true}[(year-1)%19+1]
+		months := 12
+		if leap {
+			months = 13
+		}
+		if n := HebrewCalendar.MonthsInYear(year); n != months {
+			t.Errorf("hebrew year %v has %v months, want %v", year, n, months)
+		}
+		switch n := hebrewYearDays(year); n {
+		case 353, 354, 355, 383, 384, 385:
+			if (n > 355) != leap {
+				t.Errorf("hebrew year %v has %v days, leap %v", year, n, leap)
+			}
+		default:
+			t.Errorf("hebrew year %v has %v days", year, n)
+		}
+		//新年不在星期天,星期三,星期五
+		switch dayNumberWeekdayA(hebrewNewYear(year)) {
+		case 7, 3, 5:
+			t.Errorf("hebrew new year %v on weekday %v", year, dayNumberWeekdayA(hebrewNewYear(year)))
+		}
+	}
+	if HebrewCalendar.MonthName(5784, 12) != "Adar I" || HebrewCalendar.MonthName(5785, 12) != "Adar" || HebrewCalendar.MonthName(5784, 13) != "Adar II" {
+		t.Errorf("hebrew Adar names = %v %v %v", HebrewCalendar.MonthName(5784, 12), HebrewCalendar.MonthName(5785, 12), HebrewCalendar.MonthName(5784, 13))
+	}
+}
+
+func TestCalendarMonthRange(t *testing.T) {
+	for _, cal := range []Calendar{GregorianCalendar, JulianCalendar, IslamicCalendar, HebrewCalendar, PersianCalendar} {
+		for _, month := range []int{0, -1, cal.MonthsInYear(2000) + 1, 100} {
+			if n := cal.MonthDays(2000, month); n != 0 {
+				t.Errorf("%v MonthDays(2000, %v) = %v, want 0", cal.Name(), month, n)
+			}
+			if name := cal.MonthName(2000, month); name != "" {
+				t.Errorf("%v MonthName(2000, %v) = %q, want empty", cal.Name(), month, name)
+			}
+		}
+	}
+	if HebrewCalendar.MonthDays(5785, 13) != 0 {
+		t.Errorf("hebrew MonthDays(5785, 13) = %v, want 0", HebrewCalendar.MonthDays(5785, 13))
+	}
+	if GregorianCalendar.MonthName(2000, 9) != "September" {
+		t.Errorf("gregorian MonthName(9) = %v", GregorianCalendar.MonthName(2000, 9))
+	}
+}
+
+func TestCalendarError(t *testing.T) {
+	cases := []struct {
+		cal              Calendar
+		year, month, day int
+	}{
+		{IslamicCalendar, 1445, 13, 1},
+		{IslamicCalendar, 1445, 2, 30},
+		{HebrewCalendar, 0, 7, 1},
+		{HebrewCalendar, 5785, 13, 1},
+		{HebrewCalendar, 5784, 2, 30},
+		{PersianCalendar, 1402, 12, 30},
+		{PersianCalendar, 3178, 1, 1},
+		{PersianCalendar, -62, 1, 1},
+	}
+	for _, c := range cases {
+		if _, err := c.cal.ToDayNumber(c.year, c.month, c.day); !errors.Is(err, ErrRange) {
+			t.Errorf("%v ToDayNumber(%v-%v-%v) error = %v, want ErrRange", c.cal.Name(), c.year, c.month, c.day, err)
+		}
+	}
+	if _, _, _, err := HebrewCalendar.FromDayNumber(hebrewNewYear(1) - 1); !errors.Is(err, ErrRange) {
+		t.Errorf("hebrew FromDayNumber(before epoch) error = %v, want ErrRange", err)
+	}
+}
+
+func TestUnixToCalendarFormat(t *testing.T) {
+	unix, _ := FormatToUnix("2024-03-20 10:20:30", "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+	cases := []struct {
+		cal  Calendar
+		want string
+	}{
+		{GregorianCalendar, "2024/03/20 March 10:20:30 080"},
+		{IslamicCalendar, "1445/09/10 Ramadan 10:20:30 246"},
+		{HebrewCalendar, "5784/13/10 Adar II 10:20:30 187"},
+		{PersianCalendar, "1403/01/01 Farvardin 10:20:30 001"},
+	}
+	for _, c := range cases {
+		s, err := UnixToCalendarFormat(unix, Zones.E8, c.cal, "%Y/%m/%d %B %H:%M:%S %j")
+		if err != nil || s != c.want {
+			t.Errorf("%v format = %q, %v, want %q", c.cal.Name(), s, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/jingyanbin/basal"
+	. "github.com/jingyanbin/timezone"
+)
+
+//不含日期和时区的一天中的时间 如: 营业时间
+type Clock struct {
+	Hour  int //时
+	Min   int //分
+	Sec   int //秒
+	Nanos int //纳秒
+}
+
+//检查纳秒
+func checkNanos(nanos int) error {
+	if nanos < 0 || nanos > 999999999 {
+		return newRangeError("nanos", int64(nanos), 0, 999999999)
+	}
+	return nil
+}
+
+//@description: 创建时间
+//@param:       hour, min, sec, nanos int "时,分,秒,纳秒"
+//@return:      Clock "时间"
+//@return:      error "错误信息" 超出范围时为 *RangeError
+func NewClock(hour, min, sec, nanos int) (Clock, error) {
+	if err := checkClock(hour, min, sec); err != nil {
+		return Clock{}, err
+	}
+	if err := checkNanos(nanos); err != nil {
+		return Clock{}, err
+	}
+	return Clock{Hour: hour, Min: min, Sec: sec, Nanos: nanos}, nil
+}
+
+//@description: 当天的秒数 -> 时间, 超出一天的部分被舍去
+//@param:       daySecond int64 "当天的秒数"
+//@return:      Clock "时间"
+func DaySecondToClock(daySecond int64) Clock {
+	daySecond = floorMod(daySecond, daySec)
+	return Clock{Hour: int(daySecond / hourSec), Min: int(daySecond % hourSec / minSec), Sec: int(daySecond % minSec)}
+}
+
+//@description: 秒级时间戳 -> 所在时区的时间
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      Clock "时间"
+func UnixToClock(unix int64, zone TimeZone) Clock {
+	return DaySecondToClock(unix + zone.Offset())
+}
+
+//@description: 时间字符串 -> 时间 如: 15:04, 15:04:05, 15:04:05.123
+//@param:       s string "时间字符串"
+//@return:      Clock "时间"
+//@return:      error "错误信息" 解析失败时为 *ParseError
+func ParseClock(s string) (Clock, error) {
+	fail := func(offset int, err error) (Clock, error) {
+		return Clock{}, &ParseError{Input: s, Layout: "%H:%M:%S", Offset: offset, Err: err}
+	}
+	var c Clock
+	var ok bool
+	if len(s) != 5 && (len(s) < 8 || (len(s) > 8 && s[8] != '.')) {
+		return fail(0, ErrSyntax)
+	}
+	if s[2] != ':' || (len(s) >= 8 && s[5] != ':') {
+		return fail(2, ErrSyntax)
+	}
+	if c.Hour, ok = fixedDigits(s[0:2]); !ok {
+		return fail(0, ErrSyntax)
+	}
+	if c.Min, ok = fixedDigits(s[3:5]); !ok {
+		return fail(3, ErrSyntax)
+	}
+	if len(s) >= 8 {
+		if c.Sec, ok = fixedDigits(s[6:8]); !ok {
+			return fail(6, ErrSyntax)
+		}
+	}
+	if len(s) > 9 {
+		frac := s[9:]
+		if len(frac) > 9 {
+			return fail(18, ErrTrailing)
+		}
+		n, ok := fixedDigits(frac)
+		if !ok {
+			return fail(9, ErrSyntax)
+		}
+		for i := len(frac); i < 9; i++ {
+			n *= 10
+		}
+		c.Nanos = n
+	} else if len(s) == 9 {
+		return fail(9, ErrSyntax)
+	}
+	if err := checkClock(c.Hour, c.Min, c.Sec); err != nil {
+		return fail(0, err)
+	}
+	return c, nil
+}
+
+//@description: 是否是有效的时间
+//@return:      bool "是否有效"
+func (my Clock) IsValid() bool {
+	return my.check() == nil
+}
+
+//检查时间, 无效时为 *RangeError
+func (my Clock) check() error {
+	if err := checkClock(my.Hour, my.Min, my.Sec); err != nil {
+		return err
+	}
+	return checkNanos(my.Nanos)
+}
+
+//@description: 当天的秒数
+//@return:      int64 "秒数"
+func (my Clock) DaySecond() int64 {
+	return int64(my.Hour*hourSec + my.Min*minSec + my.Sec)
+}
+
+//@description: 当天的纳秒数
+//@return:      int64 "纳秒数"
+func (my Clock) DayNanos() int64 {
+	return my.DaySecond()*1e9 + int64(my.Nanos)
+}
+
+//@description: 加N秒, 超过24时或小于0时循环
+//@param:       n int64 "秒数" 负数为N秒前
+//@return:      Clock "时间"
+//@return:      int64 "跨越的天数" 如: 23:00加2小时为01:00, 跨越1天
+func (my Clock) AddSeconds(n int64) (Clock, int64) {
+	total := my.DaySecond() + n
+	c := DaySecondToClock(total)
+	c.Nanos = my.Nanos
+	return c, floorDiv(total, daySec)
+}
+
+//@description: 加N纳秒, 超过24时或小于0时循环
+//@param:       n int64 "纳秒数"
+//@return:      Clock "时间"
+//@return:      int64 "跨越的天数"
+func (my Clock) AddNanos(n int64) (Clock, int64) {
+	nanos := int64(my.Nanos) + n
+	c, days := Clock{Hour: my.Hour, Min: my.Min, Sec: my.Sec}.AddSeconds(floorDiv(nanos, 1e9))
+	c.Nanos = int(floorMod(nanos, 1e9))
+	return c, days
+}
+
+//@description: 与other相差的纳秒数
+//@param:       other Clock "时间"
+//@return:      int64 "纳秒数" other在之后时为负数
+func (my Clock) Sub(other Clock) int64 {
+	return my.DayNanos() - other.DayNanos()
+}
+
+//@description: 比较时间
+//@param:       other Clock "时间"
+//@return:      int "-1: 之前, 0: 相同, 1: 之后"
+func (my Clock) Compare(other Clock) int {
+	switch a, b := my.DayNanos(), other.DayNanos(); {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//@description: 是否在other之前
+func (my Clock) Before(other Clock) bool {
+	return my.Compare(other) < 0
+}
+
+//@description: 是否在other之后
+func (my Clock) After(other Clock) bool {
+	return my.Compare(other) > 0
+}
+
+//@description: 格式化时间, 日期指令按1970年1月1日处理
+//@param:       formatter string "格式化模板" 如: %H:%M, %I:%M %p
+//@return:      string "时间字符串" 时间无效时为 %!Clock(24:00:00.0)
+func (my Clock) Format(formatter string) string {
+	return my.FormatLocale(formatter, LocaleEnglish)
+}
+
+//@description: 按语言环境格式化时间
+//@param:       formatter string "格式化模板" 如: %p%OI时%OM分
+//@param:       locale *Locale "语言环境"
+//@return:      string "时间字符串" 时间无效时为 %!Clock(24:00:00.0)
+func (my Clock) FormatLocale(formatter string, locale *Locale) string {
+	if !my.IsValid() {
+		return fmt.Sprintf("%%!Clock(%02d:%02d:%02d.%d)", my.Hour, my.Min, my.Sec, my.Nanos)
+	}
+	return formatDateClock(1970, 1, 1, my.Hour, my.Min, my.Sec, 0, formatter, locale)
+}
+
+//@description: 标准时间字符串 如: 15:04:05, 有纳秒时为 15:04:05.5
+func (my Clock) String() string {
+	s := my.Format("%H:%M:%S")
+	if my.Nanos == 0 || !my.IsValid() {
+		return s
+	}
+	return s + "." + strings.TrimRight(strconv.Itoa(my.Nanos + 1e9)[1:], "0")
+}
+
+//@description: 组合为指定时区的 DateTime, 忽略纳秒
+//@param:       date Date "日期"
+//@param:       zone TimeZone "时区"
+//@return:      *DateTime "日期时间"
+//@return:      error "错误信息"
+func (my Clock) On(date Date, zone TimeZone) (*DateTime, error) {
+	return date.At(my, zone)
+}
+
+//@description: 格式为 "15:04:05", 时间无效时返回 *RangeError
+func (my Clock) MarshalJSON() ([]byte, error) {
+	if err := my.check(); err != nil {
+		return nil, err
+	}
+	return []byte(`"` + my.String() + `"`), nil
+}
+
+//@description: 接受 null, "15:04" 和 "15:04:05.123"
+func (my *Clock) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return NewError("clock json error: %v", s)
+	}
+	c, err := ParseClock(s[1 : len(s)-1])
+	if err != nil {
+		return err
+	}
+	*my = c
+	return nil
+}
+
+//@description: 数据库写入, 时间无效时返回 *RangeError
+func (my Clock) Value() (driver.Value, error) {
+	if err := my.check(); err != nil {
+		return nil, err
+	}
+	return my.String(), nil
+}
+
+//@description: 数据库读取, 接受 time.Time 和 "15:04:05"
+func (my *Clock) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*my = Clock{}
+		return nil
+	case time.Time:
+		*my = Clock{Hour: v.Hour(), Min: v.Minute(), Sec: v.Second(), Nanos: v.Nanosecond()}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return NewError("clock scan error: unsupported type %T", src)
+	}
+	c, err := ParseClock(s)
+	if err != nil {
+		return err
+	}
+	*my = c
+	return nil
+}
+
+//@description: 所在时区的时间
+//@return:      Clock "时间"
+func (my *DateTime) Clock() Clock {
+	return Clock{Hour: my.hour, Min: my.min, Sec: my.sec}
+}
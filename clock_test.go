@@ -0,0 +1,145 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	cases := []struct {
+		s    string
+		want Clock
+	}{
+		{"15:04", Clock{15, 4, 0, 0}},
+		{"15:04:05", Clock{15, 4, 5, 0}},
+		{"00:00:00", Clock{}},
+		{"23:59:59", Clock{23, 59, 59, 0}},
+		{"15:04:05.1", Clock{15, 4, 5, 1e8}},
+		{"15:04:05.123", Clock{15, 4, 5, 123e6}},
+		{"15:04:05.000000001", Clock{15, 4, 5, 1}},
+		{"15:04:05.123456789", Clock{15, 4, 5, 123456789}},
+	}
+	for _, c := range cases {
+		if got, err := ParseClock(c.s); err != nil || got != c.want {
+			t.Errorf("ParseClock(%q) = %v, %v, want %v", c.s, got, err, c.want)
+		}
+	}
+}
+
+func TestParseClockError(t *testing.T) {
+	cases := []struct {
+		s      string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrSyntax},
+		{"1504", 0, ErrSyntax},
+		{"15:0", 0, ErrSyntax},
+		{"15:04:0", 0, ErrSyntax},
+		{"15:04:05x", 0, ErrSyntax},
+		{"15-04", 2, ErrSyntax},
+		{"15:04-05", 2, ErrSyntax},
+		{"1a:04", 0, ErrSyntax},
+		{"15:0a", 3, ErrSyntax},
+		{"15:04:0a", 6, ErrSyntax},
+		{"15:04:05.", 9, ErrSyntax},
+		{"15:04:05.12a", 9, ErrSyntax},
+		{"15:04:05.1234567890", 18, ErrTrailing},
+		{"24:00", 0, ErrRange},
+		{"12:60:00", 0, ErrRange},
+		{"12:00:60", 0, ErrRange},
+	}
+	for _, c := range cases {
+		_, err := ParseClock(c.s)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseClock(%q) error = %v, want *ParseError", c.s, err)
+			continue
+		}
+		if pe.Offset != c.offset || !errors.Is(err, c.err) {
+			t.Errorf("ParseClock(%q) error = %v offset %d, want %v offset %d", c.s, err, pe.Offset, c.err, c.offset)
+		}
+	}
+}
+
+func TestClockFormat(t *testing.T) {
+	cases := []struct {
+		clock Clock
+		want  string
+	}{
+		{Clock{}, "00:00:00"},
+		{Clock{15, 4, 5, 0}, "15:04:05"},
+		{Clock{15, 4, 5, 5e8}, "15:04:05.5"},
+		{Clock{15, 4, 5, 1}, "15:04:05.000000001"},
+		{Clock{Hour: 24}, "%!Clock(24:00:00.0)"},
+		{Clock{Nanos: 1e9}, "%!Clock(00:00:00.1000000000)"},
+	}
+	for _, c := range cases {
+		if got := c.clock.String(); got != c.want {
+			t.Errorf("%#v.String() = %q, want %q", c.clock, got, c.want)
+		}
+	}
+	if got := (Clock{15, 4, 5, 0}).Format("%I:%M %p"); got != "03:04 PM" {
+		t.Errorf("Format = %q", got)
+	}
+}
+
+func TestClockJSON(t *testing.T) {
+	for _, c := range []Clock{{}, {15, 4, 5, 0}, {15, 4, 5, 5e8}, {23, 59, 59, 999999999}} {
+		data, err := c.MarshalJSON()
+		if err != nil {
+			t.Errorf("%v.MarshalJSON() error: %v", c, err)
+			continue
+		}
+		var got Clock
+		if err = got.UnmarshalJSON(data); err != nil || got != c {
+			t.Errorf("UnmarshalJSON(%s) = %v, %v, want %v", data, got, err, c)
+		}
+	}
+	got := Clock{1, 2, 3, 4}
+	if err := got.UnmarshalJSON([]byte("null")); err != nil || got != (Clock{1, 2, 3, 4}) {
+		t.Errorf("UnmarshalJSON(null) = %v, %v", got, err)
+	}
+	if err := got.UnmarshalJSON([]byte(`"15:04"`)); err != nil || got != (Clock{15, 4, 0, 0}) {
+		t.Errorf(`UnmarshalJSON("15:04") = %v, %v`, got, err)
+	}
+	for _, s := range []string{`15:04`, `"25:00"`, `"`} {
+		if err := got.UnmarshalJSON([]byte(s)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %v, want error", s, got)
+		}
+	}
+	if _, err := (Clock{Min: 60}).MarshalJSON(); !errors.Is(err, ErrRange) {
+		t.Errorf("invalid clock MarshalJSON error = %v", err)
+	}
+}
+
+func TestClockSQL(t *testing.T) {
+	if v, err := (Clock{15, 4, 5, 5e8}).Value(); v != "15:04:05.5" || err != nil {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if _, err := (Clock{Nanos: -1}).Value(); !errors.Is(err, ErrRange) {
+		t.Errorf("invalid clock Value error = %v", err)
+	}
+	sources := []struct {
+		src  interface{}
+		want Clock
+	}{
+		{"15:04:05", Clock{15, 4, 5, 0}},
+		{[]byte("15:04:05.25"), Clock{15, 4, 5, 25e7}},
+		{time.Date(2020, 9, 12, 15, 4, 5, 6, time.UTC), Clock{15, 4, 5, 6}},
+		{nil, Clock{}},
+	}
+	for _, c := range sources {
+		got := Clock{1, 1, 1, 1}
+		if err := got.Scan(c.src); err != nil || got != c.want {
+			t.Errorf("Scan(%v) = %v, %v, want %v", c.src, got, err, c.want)
+		}
+	}
+	var got Clock
+	for _, src := range []interface{}{150405, "15:04:05 PM", "24:00:00"} {
+		if err := got.Scan(src); err == nil {
+			t.Errorf("Scan(%v) = %v, want error", src, got)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	. "github.com/jingyanbin/basal"
+	. "github.com/jingyanbin/timezone"
+)
+
+//不含时间和时区的日期 如: 生日, 纪念日
+type Date struct {
+	Year  int //年
+	Month int //月
+	Day   int //日
+}
+
+//@description: 创建日期
+//@param:       year, month, day int "年,月,日"
+//@return:      Date "日期"
+//@return:      error "错误信息" 超出范围时为 *RangeError
+func NewDate(year, month, day int) (Date, error) {
+	if err := checkDateClock(year, month, day, 0, 0, 0); err != nil {
+		return Date{}, err
+	}
+	return Date{Year: year, Month: month, Day: day}, nil
+}
+
+//@description: 1970年1月1日以来的天数 -> 日期
+//@param:       days int64 "天数"
+//@return:      Date "日期"
+func DayNumberToDate(days int64) Date {
+	year, month, day := dayNumberDate(days)
+	return Date{Year: year, Month: month, Day: day}
+}
+
+//@description: 秒级时间戳 -> 所在时区的日期
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      Date "日期"
+func UnixToDate(unix int64, zone TimeZone) Date {
+	return DayNumberToDate(localDayNumber(unix, zone))
+}
+
+//@description: 今天的日期
+//@param:       zone TimeZone "时区"
+//@return:      Date "日期"
+func Today(zone TimeZone) Date {
+	return UnixToDate(Unix(), zone)
+}
+
+//@description: 标准日期字符串 -> 日期 如: 2020-09-12
+//@param:       s string "日期字符串"
+//@return:      Date "日期"
+//@return:      error "错误信息" 解析失败时为 *ParseError
+func ParseDate(s string) (Date, error) {
+	return FormatToDateOptions(s, "%Y-%m-%d", StrictParse)
+}
+
+//@description: 按解析选项把日期字符串 -> 日期, 模板中的时间指令被忽略
+//@param:       s string "日期字符串"
+//@param:       formatter string "格式化模板" 如: %Y年%m月%d日
+//@param:       opts ParseOptions "解析选项"
+//@return:      Date "日期"
+//@return:      error "错误信息"
+func FormatToDateOptions(s, formatter string, opts ParseOptions) (Date, error) {
+	year, month, day, _, _, _, err := FormatToDateClockOptions(s, formatter, opts)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Year: year, Month: month, Day: day}, nil
+}
+
+//@description: 是否是零值
+//@return:      bool "是否是零值"
+func (my Date) IsZero() bool {
+	return my == Date{}
+}
+
+//@description: 是否是有效的日期
+//@return:      bool "是否有效"
+func (my Date) IsValid() bool {
+	return my.check() == nil
+}
+
+//检查日期, 无效时为 *RangeError
+func (my Date) check() error {
+	return checkDateClock(my.Year, my.Month, my.Day, 0, 0, 0)
+}
+
+//@description: 1970年1月1日以来的天数
+//@return:      int64 "天数"
+func (my Date) DayNumber() int64 {
+	return dateDayNumber(my.Year, my.Month, my.Day)
+}
+
+//@description: 星期几(1-7, 星期1为周的开始)
+//@return:      int "星期几"
+func (my Date) WeekdayA() int {
+	return dayNumberWeekdayA(my.DayNumber())
+}
+
+//@description: 星期几(0-6, 星期天为周的开始)
+//@return:      int "星期几"
+func (my Date) WeekdayB() int {
+	return my.WeekdayA() % 7
+}
+
+//@description: 年中的第几天(从1开始)
+//@return:      int "天数"
+func (my Date) YDay() int {
+	return int(my.DayNumber()-dateDayNumber(my.Year, 1, 1)) + 1
+}
+
+//@description: 加N天
+//@param:       n int "天数" 负数为N天前
+//@return:      Date "日期"
+func (my Date) AddDays(n int) Date {
+	return DayNumberToDate(my.DayNumber() + int64(n))
+}
+
+//@description: 加N个月, 日超出该月天数时取月末 如: 1月31日加1个月为2月28日或29日
+//@param:       n int "月数" 负数为N个月前
+//@return:      Date "日期"
+func (my Date) AddMonths(n int) Date {
+	total := int64(my.Year)*12 + int64(my.Month-1) + int64(n)
+	year, month := int(floorDiv(total, 12)), int(floorMod(total, 12))+1
+	day := my.Day
+	if maxDay := GregorianCalendar.MonthDays(year, month); day > maxDay {
+		day = maxDay
+	}
+	return Date{Year: year, Month: month, Day: day}
+}
+
+//@description: 加N年, 2月29日在平年为2月28日
+//@param:       n int "年数" 负数为N年前
+//@return:      Date "日期"
+func (my Date) AddYears(n int) Date {
+	return my.AddMonths(n * 12)
+}
+
+//@description: 加上时长的年,月,日部分, 先加年和月再加日, 时间部分被忽略
+//@param:       p Period "时长"
+//@return:      Date "日期"
+func (my Date) AddPeriod(p Period) Date {
+	return my.AddMonths(p.Years*12 + p.Months).AddDays(p.Days)
+}
+
+//@description: 到to相差的天数
+//@param:       to Date "日期"
+//@return:      int64 "天数" to在之前时为负数
+func (my Date) DaysUntil(to Date) int64 {
+	return to.DayNumber() - my.DayNumber()
+}
+
+//@description: 比较日期
+//@param:       other Date "日期"
+//@return:      int "-1: 之前, 0: 相同, 1: 之后"
+func (my Date) Compare(other Date) int {
+	switch a, b := my.DayNumber(), other.DayNumber(); {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//@description: 是否在other之前
+func (my Date) Before(other Date) bool {
+	return my.Compare(other) < 0
+}
+
+//@description: 是否在other之后
+func (my Date) After(other Date) bool {
+	return my.Compare(other) > 0
+}
+
+//@description: 格式化日期, 时间指令按0时处理
+//@param:       formatter string "格式化模板" 如: %Y年%m月%d日 %A
+//@return:      string "日期字符串" 日期无效(包括零值)时为 %!Date(2021-02-30)
+func (my Date) Format(formatter string) string {
+	return my.FormatLocale(formatter, LocaleEnglish)
+}
+
+//@description: 按语言环境格式化日期
+//@param:       formatter string "格式化模板"
+//@param:       locale *Locale "语言环境"
+//@return:      string "日期字符串" 日期无效时为 %!Date(2021-02-30)
+func (my Date) FormatLocale(formatter string, locale *Locale) string {
+	if !my.IsValid() {
+		return fmt.Sprintf("%%!Date(%04d-%02d-%02d)", my.Year, my.Month, my.Day)
+	}
+	return formatDateClock(my.Year, my.Month, my.Day, 0, 0, 0, 0, formatter, locale)
+}
+
+//@description: 标准日期字符串 如: 2020-09-12
+func (my Date) String() string {
+	return my.Format("%Y-%m-%d")
+}
+
+//@description: 与时间组合为指定时区的 DateTime, 忽略纳秒
+//@param:       clock Clock "时间"
+//@param:       zone TimeZone "时区"
+//@return:      *DateTime "日期时间"
+//@return:      error "错误信息"
+func (my Date) At(clock Clock, zone TimeZone) (*DateTime, error) {
+	return DateClockToDateTime(my.Year, my.Month, my.Day, clock.Hour, clock.Min, clock.Sec, zone)
+}
+
+//@description: 指定时区当天0时的秒级时间戳
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func (my Date) Unix(zone TimeZone) int64 {
+	return my.DayNumber()*daySec - zone.Offset()
+}
+
+//@description: 零值为 null, 否则为 "2020-09-12", 日期无效时返回 *RangeError
+func (my Date) MarshalJSON() ([]byte, error) {
+	if my.IsZero() {
+		return []byte("null"), nil
+	}
+	if err := my.check(); err != nil {
+		return nil, err
+	}
+	return []byte(`"` + my.String() + `"`), nil
+}
+
+//@description: 接受 null 和 "2020-09-12"
+func (my *Date) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return NewError("date json error: %v", s)
+	}
+	d, err := ParseDate(s[1 : len(s)-1])
+	if err != nil {
+		return err
+	}
+	*my = d
+	return nil
+}
+
+//@description: 数据库写入, 零值为 NULL, 日期无效时返回 *RangeError
+func (my Date) Value() (driver.Value, error) {
+	if my.IsZero() {
+		return nil, nil
+	}
+	if err := my.check(); err != nil {
+		return nil, err
+	}
+	return my.String(), nil
+}
+
+//@description: 数据库读取, 接受 NULL, time.Time 和 "2020-09-12"(之后的时间部分被忽略)
+func (my *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*my = Date{}
+		return nil
+	case time.Time:
+		*my = Date{Year: v.Year(), Month: int(v.Month()), Day: v.Day()}
+		return nil
+	case []byte:
+		return my.scanString(string(v))
+	case string:
+		return my.scanString(v)
+	}
+	return NewError("date scan error: unsupported type %T", src)
+}
+
+func (my *Date) scanString(s string) error {
+	if len(s) > 10 && (s[10] == ' ' || s[10] == 'T') {
+		s = s[:10]
+	}
+	d, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*my = d
+	return nil
+}
+
+//@description: 所在时区的日期
+//@return:      Date "日期"
+func (my *DateTime) Date() Date {
+	return Date{Year: my.year, Month: my.month, Day: my.day}
+}
@@ -0,0 +1,154 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestDateAddMonths(t *testing.T) {
+	cases := []struct {
+		date Date
+		n    int
+		want Date
+	}{
+		{Date{2020, 1, 31}, 1, Date{2020, 2, 29}},
+		{Date{2021, 1, 31}, 1, Date{2021, 2, 28}},
+		{Date{2020, 3, 31}, -1, Date{2020, 2, 29}},
+		{Date{2020, 3, 31}, 1, Date{2020, 4, 30}},
+		{Date{2020, 12, 15}, 1, Date{2021, 1, 15}},
+		{Date{2020, 1, 15}, -1, Date{2019, 12, 15}},
+		{Date{2020, 1, 31}, 13, Date{2021, 2, 28}},
+		{Date{2020, 1, 31}, -25, Date{2017, 12, 31}},
+		{Date{2020, 5, 31}, 0, Date{2020, 5, 31}},
+	}
+	for _, c := range cases {
+		if got := c.date.AddMonths(c.n); got != c.want {
+			t.Errorf("%v.AddMonths(%d) = %v, want %v", c.date, c.n, got, c.want)
+		}
+	}
+	if got := (Date{2020, 2, 29}).AddYears(1); got != (Date{2021, 2, 28}) {
+		t.Errorf("AddYears(1) = %v", got)
+	}
+	if got := (Date{2020, 2, 29}).AddYears(4); got != (Date{2024, 2, 29}) {
+		t.Errorf("AddYears(4) = %v", got)
+	}
+	if got := (Date{2020, 1, 31}).AddPeriod(Period{Months: 1, Days: 1}); got != (Date{2020, 3, 1}) {
+		t.Errorf("AddPeriod = %v", got)
+	}
+}
+
+func TestDateAt(t *testing.T) {
+	date := Date{2020, 9, 16}
+	dt, err := date.At(Clock{14, 30, 15, 5e8}, Zones.E8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt.Unix() != relativeRef {
+		t.Errorf("At = %d, want %d", dt.Unix(), relativeRef)
+	}
+	if dt.Date() != date || dt.Clock() != (Clock{14, 30, 15, 0}) {
+		t.Errorf("At = %v %v", dt.Date(), dt.Clock())
+	}
+	if dt, err = (Clock{14, 30, 15, 0}).On(date, Zones.E8); err != nil || dt.Unix() != relativeRef {
+		t.Errorf("On = %v, %v", dt, err)
+	}
+	if _, err = (Date{2021, 2, 29}).At(Clock{}, Zones.E8); !errors.Is(err, ErrRange) {
+		t.Errorf("invalid date At error = %v", err)
+	}
+	if _, err = date.At(Clock{Hour: 24}, Zones.E8); !errors.Is(err, ErrRange) {
+		t.Errorf("invalid clock At error = %v", err)
+	}
+	if got := date.Unix(Zones.E8); got != relativeRef-(14*hourSec+30*minSec+15) {
+		t.Errorf("Unix = %d", got)
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	cases := []struct {
+		date      Date
+		formatter string
+		want      string
+	}{
+		{Date{2020, 9, 12}, "%Y年%m月%d日 %A %B %H:%M", "2020年09月12日 Saturday September 00:00"},
+		{Date{2020, 9, 12}, "", ""},
+		{Date{}, "%B", "%!Date(0000-00-00)"},
+		{Date{2021, 2, 29}, "%Y-%m-%d", "%!Date(2021-02-29)"},
+		{Date{2020, 13, 1}, "%b", "%!Date(2020-13-01)"},
+	}
+	for _, c := range cases {
+		if got := c.date.Format(c.formatter); got != c.want {
+			t.Errorf("%#v.Format(%q) = %q, want %q", c.date, c.formatter, got, c.want)
+		}
+	}
+	if got := (Date{}).String(); got != "%!Date(0000-00-00)" {
+		t.Errorf("Date{}.String() = %q", got)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	cases := []struct {
+		date Date
+		json string
+	}{
+		{Date{2020, 9, 12}, `"2020-09-12"`},
+		{Date{-44, 3, 15}, `"-0044-03-15"`},
+		{Date{}, `null`},
+	}
+	for _, c := range cases {
+		data, err := c.date.MarshalJSON()
+		if err != nil || string(data) != c.json {
+			t.Errorf("%v.MarshalJSON() = %s, %v, want %s", c.date, data, err, c.json)
+			continue
+		}
+		var d Date
+		if err = d.UnmarshalJSON(data); err != nil || d != c.date {
+			t.Errorf("UnmarshalJSON(%s) = %v, %v", data, d, err)
+		}
+	}
+	if _, err := (Date{2021, 2, 29}).MarshalJSON(); !errors.Is(err, ErrRange) {
+		t.Errorf("invalid date MarshalJSON error = %v", err)
+	}
+	for _, s := range []string{`2020-09-12`, `"2020-13-01"`, `"2020-9-12"`, `"`} {
+		var d Date
+		if err := d.UnmarshalJSON([]byte(s)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %v, want error", s, d)
+		}
+	}
+}
+
+func TestDateSQL(t *testing.T) {
+	if v, err := (Date{}).Value(); v != nil || err != nil {
+		t.Errorf("Date{}.Value() = %v, %v", v, err)
+	}
+	if v, err := (Date{2020, 9, 12}).Value(); v != "2020-09-12" || err != nil {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if _, err := (Date{2020, 0, 12}).Value(); !errors.Is(err, ErrRange) {
+		t.Errorf("invalid date Value error = %v", err)
+	}
+	want := Date{2020, 9, 12}
+	sources := []interface{}{
+		"2020-09-12",
+		"2020-09-12 14:30:15",
+		[]byte("2020-09-12T14:30:15Z"),
+		time.Date(2020, 9, 12, 23, 0, 0, 0, time.FixedZone("CST", 8*hourSec)),
+	}
+	for _, src := range sources {
+		d := Date{1, 1, 1}
+		if err := d.Scan(src); err != nil || d != want {
+			t.Errorf("Scan(%v) = %v, %v", src, d, err)
+		}
+	}
+	d := want
+	if err := d.Scan(nil); err != nil || !d.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v", d, err)
+	}
+	for _, src := range []interface{}{20200912, "2020/09/12", "2020-02-30"} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("Scan(%v) = %v, want error", src, d)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package datetime
 
 import (
-	. "github.com/jingyanbin/basal"
 	. "github.com/jingyanbin/timezone"
 )
 
@@ -80,6 +79,18 @@ func (my *DateTime) YearWeekNumB() int {
 	return UnixYearWeekNumB(my.unix, my.zone)
 }
 
+//@description: 返回星期数, 星期1为一周的开始 与 %W 一致
+//@return:      int(0-53) "第几周"
+func (my *DateTime) YearWeekNumW() int {
+	return UnixYearWeekNumW(my.unix, my.zone)
+}
+
+//@description: 返回星期数, 星期天为一周的开始 与 %U 一致
+//@return:      int(0-53) "第几周"
+func (my *DateTime) YearWeekNumU() int {
+	return UnixYearWeekNumU(my.unix, my.zone)
+}
+
 //@description: 返回周几, 星期1为一周的开始
 //@return:      week int "星期(1-7)"
 func (my *DateTime) WeekdayA() int {
@@ -149,11 +160,7 @@ func (my *DateTime) FlushToDateClock(year, month, day, hour, min, sec int) error
 //@param:       extend bool "是否启用扩展模式" 与函数 FormatToDateClock 一样
 //@return:      error "错误信息"
 func (my *DateTime) FlushToFormat(s, formatter string, extend bool) error {
-	year, month, day, hour, min, sec, err := FormatToDateClock(s, formatter, extend)
-	if err != nil {
-		return err
-	}
-	return my.FlushToDateClock(year, month, day, hour, min, sec)
+	return my.FlushToFormatOptions(s, formatter, extendOptions(extend))
 }
 
 //@description: 刷新时间到 标准日期时间字符串
@@ -186,7 +193,7 @@ func (my *DateTime) UnixDayZeroHour() int64 {
 //@description: 返回本小时0分的秒级时间戳
 //@return:      int64 "秒级时间戳"
 func (my *DateTime) UnixHourZeroMin() int64 {
-	return UnixHourZeroMin(my.unix)
+	return UnixHourZeroMinZone(my.unix, my.zone)
 }
 
 //@description: 返回N天后特定时间的秒级时间戳
@@ -235,60 +242,15 @@ func (my *DateTime)UnixFutureWeekDayB(week, hour, min, sec int) (int64, error){
 //@param:       formatter string "格式化字符串"
 //@return:      string "日期时间字符串"
 func (my *DateTime) Format(formatter string) string {
-	var theTime []byte
-	length := len(formatter)
-	for i := 0; i < length; {
-		c := formatter[i]
-		if c == '%' {
-			if i+1 == length {
-				break
-			}
-			c2 := formatter[i+1]
-			switch c2 {
-			case 'Y': //四位数的年份表示（0000-9999）
-				ItoAW(&theTime, my.year, 4)
-			case 'y': //两位数的年份表示（00-99）
-				ItoAW(&theTime, my.year, 2)
-			case 'm': //月份（01-12）
-				ItoAW(&theTime, my.month, 2)
-			case 'd': //月内中的一天（0-31）
-				ItoAW(&theTime, my.day, 2)
-			case 'H': //24小时制小时数（0-23）
-				ItoAW(&theTime, my.hour, 2)
-			case 'I': //12小时制小时数（01-12）
-				hour12 := my.hour % 12
-				if hour12 == 0 {
-					hour12 = 12
-				}
-				ItoAW(&theTime, hour12, 2)
-			case 'M': //分钟数（00=59）
-				ItoAW(&theTime, my.min, 2)
-			case 'S': //秒（00-59）
-				ItoAW(&theTime, my.sec, 2)
-			case 'j': //一年内的第几天
-				ItoAW(&theTime, my.yDay, 3)
-			case 'p': //本地A.M.或P.M.的等价符
-				if my.hour < 12 {
-					theTime = append(theTime, "AM"...)
-				} else {
-					theTime = append(theTime, "PM"...)
-				}
-			case 'U': //一年中的星期数（00-53）星期天为星期的开始
-				ItoAW(&theTime, my.YearWeekNumB(), 2)
-			case 'w': //星期（0-6），星期天为星期的开始
-				ItoAW(&theTime, my.WeekdayB(), 1)
-			case 'W': //一年中的星期数（00-53）星期一为星期的开始
-				ItoAW(&theTime, my.YearWeekNumA(), 2)
-			default:
-				theTime = append(theTime, c2)
-			}
-			i += 2
-		} else {
-			theTime = append(theTime, c)
-			i += 1
-		}
-	}
-	return string(theTime)
+	return my.FormatLocale(formatter, LocaleEnglish)
+}
+
+//@description: 返回按语言环境格式化的日期时间字符串
+//@param:       formatter string "格式化字符串" 数字指令前加O使用中文数字 如: "%OY年%Om月%Od日 %A"
+//@param:       locale *Locale "语言环境" 如: LocaleEnglish, LocaleChinese
+//@return:      string "日期时间字符串"
+func (my *DateTime) FormatLocale(formatter string, locale *Locale) string {
+	return formatDateClock(my.year, my.month, my.day, my.hour, my.min, my.sec, my.zone.Offset(), formatter, locale)
 }
 
 //@description: 返回标准日期时间字符串
@@ -315,12 +277,7 @@ func UnixToDateTime(unix int64, zone TimeZone) (dt *DateTime) {
 
 //@description: 格式化日期时间字符串 转换为DateTime
 func FormatToDateTime(s, formatter string, zone TimeZone, extend bool) (dt *DateTime, err error) {
-	dt = &DateTime{zone: zone}
-	err = dt.FlushToFormat(s, formatter, extend)
-	if err != nil {
-		return nil, err
-	}
-	return dt, nil
+	return FormatToDateTimeOptions(s, formatter, zone, extendOptions(extend))
 }
 
 //@description: 标准日期时间字符串 转换为DateTime
@@ -0,0 +1,73 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+)
+
+//可用 errors.Is 判断的错误类型
+var (
+	ErrSyntax        = errors.New("syntax error")                //日期时间字符串与格式化模板不匹配
+	ErrFormatter     = errors.New("unknown formatter directive") //格式化模板中有无法识别的指令
+	ErrRange         = errors.New("out of range")                //字段值超出范围
+	ErrTrailing      = errors.New("trailing unparsed input")     //日期时间字符串末尾有未解析的内容
+	ErrMissing       = errors.New("missing field")               //格式化模板中缺少年,月,日
+	ErrUnknownLayout = errors.New("unknown layout")              //无法识别日期时间字符串的格式
+)
+
+//日期时间字符串解析错误
+type ParseError struct {
+	Input     string //日期时间字符串
+	Layout    string //格式化模板
+	Offset    int    //出错位置在日期时间字符串中的字节偏移, -1表示字符串中不存在该字段
+	Directive string //出错的模板指令 如: "%Y", 分隔符出错时为分隔符本身
+	Err       error  //出错原因: ErrSyntax, ErrFormatter, ErrTrailing, ErrMissing 或 *RangeError
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error: time=%v, formatter=%v, offset=%v, directive=%v, err=%v", e.Input, e.Layout, e.Offset, e.Directive, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+//字段值超出范围错误
+type RangeError struct {
+	Field string //字段名 如: year, month, day, hour, min, sec, week
+	Value int64  //实际值
+	Min   int64  //最小值
+	Max   int64  //最大值
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("out of range %v=%v(%v,%v)", e.Field, e.Value, e.Min, e.Max)
+}
+
+//使 errors.Is(err, ErrRange) 成立
+func (e *RangeError) Is(target error) bool {
+	return target == ErrRange
+}
+
+func newRangeError(field string, value, min, max int64) *RangeError {
+	return &RangeError{Field: field, Value: value, Min: min, Max: max}
+}
+
+//字段对应的格式化模板指令
+func fieldDirective(field string) string {
+	switch field {
+	case "year":
+		return "%Y"
+	case "month":
+		return "%m"
+	case "day":
+		return "%d"
+	case "hour":
+		return "%H"
+	case "min":
+		return "%M"
+	case "sec":
+		return "%S"
+	}
+	return ""
+}
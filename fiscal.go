@@ -0,0 +1,202 @@
+package datetime
+
+import (
+	. "github.com/jingyanbin/timezone"
+)
+
+//财年季度中每个会计期的周数模式
+type FiscalPattern int
+
+const (
+	FiscalPattern445 FiscalPattern = iota + 1 //4-4-5
+	FiscalPattern454                          //4-5-4
+	FiscalPattern544                          //5-4-4
+)
+
+func (p FiscalPattern) weeks() (w [3]int, ok bool) {
+	switch p {
+	case FiscalPattern445:
+		return [3]int{4, 4, 5}, true
+	case FiscalPattern454:
+		return [3]int{4, 5, 4}, true
+	case FiscalPattern544:
+		return [3]int{5, 4, 4}, true
+	}
+	return w, false
+}
+
+//财年日期
+type FiscalDate struct {
+	Year       int //财年, 以财年开始月所在的公历年命名
+	Quarter    int //季度(1-4)
+	Period     int //会计期(1-12)
+	Week       int //财年中第几周(1-53)
+	PeriodWeek int //会计期中第几周(1-6)
+	YearDay    int //财年中第几天(1-371)
+}
+
+//财年日历(52/53周制)
+//财年从 startMonth 月1日附近的 weekStart 开始, 每年52周或53周, 53周年份的多出一周计入第12个会计期
+type FiscalCalendar struct {
+	startMonth int //财年开始月(1-12)
+	weekStart  int //每周开始的星期(1-7)
+	pattern    FiscalPattern
+	nearest    bool //true: 取离 startMonth 月1日最近的 weekStart, false: 取 startMonth 月1日当天或之后的第一个 weekStart
+}
+
+//@description: 创建财年日历
+//@param:       startMonth int "财年开始月(1-12)"
+//@param:       weekStart int "每周开始的星期(1-7)"
+//@param:       pattern FiscalPattern "会计期周数模式"
+//@param:       nearest bool "财年开始日规则" true:离开始月1日最近的weekStart, false:开始月1日当天或之后的第一个weekStart
+//@return:      *FiscalCalendar "财年日历"
+//@return:      error "错误信息"
+func NewFiscalCalendar(startMonth, weekStart int, pattern FiscalPattern, nearest bool) (*FiscalCalendar, error) {
+	if startMonth < 1 || startMonth > 12 {
+		return nil, newRangeError("month", int64(startMonth), 1, 12)
+	}
+	if weekStart < 1 || weekStart > 7 {
+		return nil, newRangeError("week", int64(weekStart), 1, 7)
+	}
+	if _, ok := pattern.weeks(); !ok {
+		return nil, newRangeError("pattern", int64(pattern), int64(FiscalPattern445), int64(FiscalPattern544))
+	}
+	return &FiscalCalendar{startMonth: startMonth, weekStart: weekStart, pattern: pattern, nearest: nearest}, nil
+}
+
+//财年第一天(1970年1月1日以来的天数)
+func (my *FiscalCalendar) yearStartDay(year int) int64 {
+	anchor := dateDayNumber(year, my.startMonth, 1)
+	diff := (my.weekStart - dayNumberWeekdayA(anchor) + 7) % 7
+	if my.nearest && diff > 3 {
+		diff -= 7
+	}
+	return anchor + int64(diff)
+}
+
+//财年中每个会计期的周数
+func (my *FiscalCalendar) periodWeeks(year int) (weeks [12]int) {
+	w, _ := my.pattern.weeks()
+	for i := 0; i < 12; i++ {
+		weeks[i] = w[i%3]
+	}
+	if my.YearWeeks(year) == 53 {
+		weeks[11] += 1
+	}
+	return
+}
+
+//@description: 返回财年的周数
+//@param:       year int "财年"
+//@return:      int "周数(52或53)"
+func (my *FiscalCalendar) YearWeeks(year int) int {
+	return int((my.yearStartDay(year+1) - my.yearStartDay(year)) / 7)
+}
+
+//@description: 秒级时间戳 -> 财年日期
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      FiscalDate "财年日期"
+func (my *FiscalCalendar) Date(unix int64, zone TimeZone) (fd FiscalDate) {
+	days := localDayNumber(unix, zone)
+	year, month, _, _, _, _, _, _ := UnixToDateClock(unix, zone)
+	if month < my.startMonth {
+		year -= 1
+	}
+	if days < my.yearStartDay(year) {
+		year -= 1
+	} else if days >= my.yearStartDay(year+1) {
+		year += 1
+	}
+	fd.Year = year
+	fd.YearDay = int(days-my.yearStartDay(year)) + 1
+	fd.Week = (fd.YearDay-1)/7 + 1
+	week := fd.Week
+	for i, n := range my.periodWeeks(year) {
+		if week <= n {
+			fd.Period = i + 1
+			fd.PeriodWeek = week
+			break
+		}
+		week -= n
+	}
+	fd.Quarter = (fd.Period-1)/3 + 1
+	return
+}
+
+//@description: 返回财年第一天0时的秒级时间戳
+//@param:       year int "财年"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func (my *FiscalCalendar) UnixYearStart(year int, zone TimeZone) int64 {
+	return my.yearStartDay(year)*daySec - zone.Offset()
+}
+
+//@description: 返回财年第N周第一天0时的秒级时间戳
+//@param:       year int "财年"
+//@param:       week int "周(1-53)"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *FiscalCalendar) UnixWeekStart(year, week int, zone TimeZone) (int64, error) {
+	if week < 1 || week > my.YearWeeks(year) {
+		return 0, newRangeError("week", int64(week), 1, int64(my.YearWeeks(year)))
+	}
+	return my.UnixYearStart(year, zone) + int64(week-1)*weekSec, nil
+}
+
+//@description: 返回财年第N个会计期第一天0时的秒级时间戳
+//@param:       year int "财年"
+//@param:       period int "会计期(1-12)"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *FiscalCalendar) UnixPeriodStart(year, period int, zone TimeZone) (int64, error) {
+	if period < 1 || period > 12 {
+		return 0, newRangeError("period", int64(period), 1, 12)
+	}
+	var weeks int
+	pWeeks := my.periodWeeks(year)
+	for i := 0; i < period-1; i++ {
+		weeks += pWeeks[i]
+	}
+	return my.UnixYearStart(year, zone) + int64(weeks)*weekSec, nil
+}
+
+//@description: 返回财年第N季度第一天0时的秒级时间戳
+//@param:       year int "财年"
+//@param:       quarter int "季度(1-4)"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func (my *FiscalCalendar) UnixQuarterStart(year, quarter int, zone TimeZone) (int64, error) {
+	if quarter < 1 || quarter > 4 {
+		return 0, newRangeError("quarter", int64(quarter), 1, 4)
+	}
+	return my.UnixPeriodStart(year, quarter*3-2, zone)
+}
+
+//@description: 财年日期 -> 秒级时间戳
+//@param:       year int "财年"
+//@param:       week int "周(1-53)"
+//@param:       weekday int "星期(1-7)"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "当天0时的秒级时间戳"
+//@return:      error "错误信息"
+func (my *FiscalCalendar) ToUnix(year, week, weekday int, zone TimeZone) (int64, error) {
+	if weekday < 1 || weekday > 7 {
+		return 0, newRangeError("week", int64(weekday), 1, 7)
+	}
+	start, err := my.UnixWeekStart(year, week, zone)
+	if err != nil {
+		return 0, err
+	}
+	return start + int64((weekday-my.weekStart+7)%7)*daySec, nil
+}
+
+//@description: 返回财年日期
+//@param:       cal *FiscalCalendar "财年日历"
+//@return:      FiscalDate "财年日期"
+func (my *DateTime) FiscalDate(cal *FiscalCalendar) FiscalDate {
+	return cal.Date(my.unix, my.zone)
+}
@@ -0,0 +1,157 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//零售日历(NRF 4-5-4): 财年从离2月1日最近的星期天开始
+func retailCalendar(t *testing.T, pattern FiscalPattern) *FiscalCalendar {
+	cal, err := NewFiscalCalendar(2, 7, pattern, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func TestFiscalYearWeeks(t *testing.T) {
+	cases := []struct {
+		nearest bool
+		year    int
+		start   string
+		weeks   int
+	}{
+		{true, 2017, "2017-01-29", 53},
+		{true, 2018, "2018-02-04", 52},
+		{true, 2022, "2022-01-30", 52},
+		{true, 2023, "2023-01-29", 53},
+		{true, 2024, "2024-02-04", 52},
+		{true, 2028, "2028-01-30", 53},
+		{false, 2022, "2022-02-06", 52},
+		{false, 2023, "2023-02-05", 52},
+		{false, 2024, "2024-02-04", 52},
+		{false, 2026, "2026-02-01", 53},
+	}
+	for _, c := range cases {
+		cal, err := NewFiscalCalendar(2, 7, FiscalPattern454, c.nearest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := cstString(cal.UnixYearStart(c.year, Zones.E8)); s != c.start+" 00:00:00" {
+			t.Errorf("nearest=%v UnixYearStart(%v) = %v, want %v", c.nearest, c.year, s, c.start)
+		}
+		if w := cal.YearWeeks(c.year); w != c.weeks {
+			t.Errorf("nearest=%v YearWeeks(%v) = %v, want %v", c.nearest, c.year, w, c.weeks)
+		}
+	}
+}
+
+func TestFiscalDate(t *testing.T) {
+	cal := retailCalendar(t, FiscalPattern454)
+	cases := []struct {
+		date string
+		want FiscalDate
+	}{
+		{"2023-01-28", FiscalDate{Year: 2022, Quarter: 4, Period: 12, Week: 52, PeriodWeek: 4, YearDay: 364}},
+		{"2023-01-29", FiscalDate{Year: 2023, Quarter: 1, Period: 1, Week: 1, PeriodWeek: 1, YearDay: 1}},
+		{"2023-02-26", FiscalDate{Year: 2023, Quarter: 1, Period: 2, Week: 5, PeriodWeek: 1, YearDay: 29}},
+		{"2023-04-29", FiscalDate{Year: 2023, Quarter: 1, Period: 3, Week: 13, PeriodWeek: 4, YearDay: 91}},
+		{"2023-04-30", FiscalDate{Year: 2023, Quarter: 2, Period: 4, Week: 14, PeriodWeek: 1, YearDay: 92}},
+		{"2024-01-27", FiscalDate{Year: 2023, Quarter: 4, Period: 12, Week: 52, PeriodWeek: 4, YearDay: 364}},
+		{"2024-02-03", FiscalDate{Year: 2023, Quarter: 4, Period: 12, Week: 53, PeriodWeek: 5, YearDay: 371}},
+		{"2024-02-04", FiscalDate{Year: 2024, Quarter: 1, Period: 1, Week: 1, PeriodWeek: 1, YearDay: 1}},
+	}
+	for _, c := range cases {
+		unix, err := FormatToUnix(c.date+" 12:00:00", "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fd := cal.Date(unix, Zones.E8); fd != c.want {
+			t.Errorf("Date(%v) = %+v, want %+v", c.date, fd, c.want)
+		}
+	}
+}
+
+func TestFiscalPattern(t *testing.T) {
+	cases := []struct {
+		pattern FiscalPattern
+		periods [12]int //每个会计期开始的周
+	}{
+		{FiscalPattern445, [12]int{1, 5, 9, 14, 18, 22, 27, 31, 35, 40, 44, 48}},
+		{FiscalPattern454, [12]int{1, 5, 10, 14, 18, 23, 27, 31, 36, 40, 44, 49}},
+		{FiscalPattern544, [12]int{1, 6, 10, 14, 19, 23, 27, 32, 36, 40, 45, 49}},
+	}
+	for _, c := range cases {
+		cal := retailCalendar(t, c.pattern)
+		for _, year := range []int{2022, 2023} {
+			start := cal.UnixYearStart(year, Zones.E8)
+			for i, week := range c.periods {
+				unix, err := cal.UnixPeriodStart(year, i+1, Zones.E8)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := start + int64(week-1)*weekSec; unix != want {
+					t.Errorf("pattern %v UnixPeriodStart(%v, %v) = %v, want %v", c.pattern, year, i+1, cstString(unix), cstString(want))
+				}
+				if fd := cal.Date(unix, Zones.E8); fd.Period != i+1 || fd.PeriodWeek != 1 || fd.Week != week || fd.Quarter != i/3+1 {
+					t.Errorf("pattern %v Date(period %v) = %+v", c.pattern, i+1, fd)
+				}
+				if i%3 == 0 {
+					q, err := cal.UnixQuarterStart(year, i/3+1, Zones.E8)
+					if err != nil || q != unix {
+						t.Errorf("pattern %v UnixQuarterStart(%v, %v) = %v, %v, want %v", c.pattern, year, i/3+1, cstString(q), err, cstString(unix))
+					}
+				}
+			}
+			//53周年份的第12个会计期多一周
+			last := cal.Date(cal.UnixYearStart(year+1, Zones.E8)-1, Zones.E8)
+			periodWeeks := cal.YearWeeks(year) - c.periods[11] + 1
+			if last.Period != 12 || last.PeriodWeek != periodWeeks {
+				t.Errorf("pattern %v last day of %v = %+v, want period week %v", c.pattern, year, last, periodWeeks)
+			}
+		}
+	}
+}
+
+func TestFiscalToUnix(t *testing.T) {
+	cal := retailCalendar(t, FiscalPattern445)
+	start := cal.UnixYearStart(2020, Zones.E8)
+	end := cal.UnixYearStart(2026, Zones.E8)
+	for unix := start; unix < end; unix += daySec {
+		fd := cal.Date(unix, Zones.E8)
+		got, err := cal.ToUnix(fd.Year, fd.Week, UnixWeekdayA(unix, Zones.E8), Zones.E8)
+		if err != nil || got != unix {
+			t.Fatalf("ToUnix(%+v) = %v, %v, want %v", fd, cstString(got), err, cstString(unix))
+		}
+	}
+	if _, err := cal.ToUnix(2022, 53, 1, Zones.E8); !errors.Is(err, ErrRange) {
+		t.Errorf("ToUnix(2022, 53) error = %v, want ErrRange", err)
+	}
+	if _, err := cal.ToUnix(2023, 53, 1, Zones.E8); err != nil {
+		t.Errorf("ToUnix(2023, 53) error = %v", err)
+	}
+	if _, err := cal.UnixPeriodStart(2023, 13, Zones.E8); !errors.Is(err, ErrRange) {
+		t.Errorf("UnixPeriodStart(13) error = %v, want ErrRange", err)
+	}
+}
+
+func TestNewFiscalCalendarError(t *testing.T) {
+	cases := []struct {
+		month, week int
+		pattern     FiscalPattern
+	}{
+		{0, 7, FiscalPattern445},
+		{13, 7, FiscalPattern445},
+		{2, 0, FiscalPattern445},
+		{2, 8, FiscalPattern445},
+		{2, 7, 0},
+		{2, 7, FiscalPattern544 + 1},
+	}
+	for _, c := range cases {
+		if _, err := NewFiscalCalendar(c.month, c.week, c.pattern, true); !errors.Is(err, ErrRange) {
+			t.Errorf("NewFiscalCalendar(%v, %v, %v) error = %v, want ErrRange", c.month, c.week, c.pattern, err)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/jingyanbin/datetime
+
+go 1.23
@@ -0,0 +1,528 @@
+package datetime
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "github.com/jingyanbin/basal"
+	. "github.com/jingyanbin/timezone"
+)
+
+//节假日类型
+type HolidayKind int
+
+const (
+	HolidayOff     HolidayKind = iota + 1 //放假
+	HolidayWorkday                        //调休上班
+)
+
+//节假日
+type Holiday struct {
+	Days int64 //1970年1月1日以来的天数, 与 UnixDayNumber 一致
+	Name string
+	Kind HolidayKind
+}
+
+type namedHolidayRule struct {
+	rule HolidayRule
+	name string
+}
+
+//节假日集合, 以1970年1月1日以来的天数为键
+type HolidaySet struct {
+	days  map[int64]Holiday
+	rules []namedHolidayRule //无结束日期的重复节假日
+}
+
+//@description: 创建节假日集合
+//@return:      *HolidaySet "节假日集合"
+func NewHolidaySet() *HolidaySet {
+	return &HolidaySet{days: map[int64]Holiday{}}
+}
+
+//@description: 添加节假日, 同一天已存在时覆盖
+//@param:       h Holiday "节假日"
+func (my *HolidaySet) Add(h Holiday) {
+	my.days[h.Days] = h
+}
+
+//@description: 添加每年重复的放假规则
+//@param:       rule HolidayRule "节假日规则"
+//@param:       name string "名称"
+func (my *HolidaySet) AddRule(rule HolidayRule, name string) {
+	my.rules = append(my.rules, namedHolidayRule{rule: rule, name: name})
+}
+
+//@description: 合并另一个节假日集合, 同一天以other为准
+//@param:       other *HolidaySet "节假日集合"
+func (my *HolidaySet) Merge(other *HolidaySet) {
+	for days, h := range other.days {
+		my.days[days] = h
+	}
+	my.rules = append(my.rules, other.rules...)
+}
+
+//@description: 查询某天的节假日
+//@param:       days int64 "1970年1月1日以来的天数"
+//@return:      Holiday "节假日"
+//@return:      bool "是否找到"
+func (my *HolidaySet) Get(days int64) (Holiday, bool) {
+	if h, ok := my.days[days]; ok {
+		return h, true
+	}
+	if len(my.rules) > 0 {
+		year, _, _ := dayNumberDate(days)
+		for _, r := range my.rules {
+			for y := year - 1; y <= year+1; y++ {
+				for _, d := range r.rule.Days(y) {
+					if d == days {
+						return Holiday{Days: days, Name: r.name, Kind: HolidayOff}, true
+					}
+				}
+			}
+		}
+	}
+	return Holiday{}, false
+}
+
+//@description: 查询时间戳所在日的节假日
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      Holiday "节假日"
+//@return:      bool "是否找到"
+func (my *HolidaySet) Lookup(unix int64, zone TimeZone) (Holiday, bool) {
+	return my.Get(localDayNumber(unix, zone))
+}
+
+//@description: 返回按日期排序的所有节假日(不含重复规则)
+//@return:      []Holiday "节假日"
+func (my *HolidaySet) Holidays() []Holiday {
+	res := make([]Holiday, 0, len(my.days))
+	for _, h := range my.days {
+		res = append(res, h)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Days < res[j].Days })
+	return res
+}
+
+//@description: 返回节假日数量(不含重复规则)
+func (my *HolidaySet) Len() int {
+	return len(my.days)
+}
+
+//@description: 将节假日集合合并到工作日日历
+//@param:       set *HolidaySet "节假日集合"
+func (my *BusinessCalendar) AddHolidaySet(set *HolidaySet) {
+	for days, h := range set.days {
+		if h.Kind == HolidayWorkday {
+			my.workdays[days] = true
+		} else {
+			my.holidays[days] = true
+		}
+	}
+	for _, r := range set.rules {
+		my.AddHolidayRule(r.rule)
+	}
+}
+
+//解析日期 如: 2024-10-01, 2024/10/1, 20241001
+func parseHolidayDate(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	formatter := "%Y-%m-%d"
+	extend := true
+	if len(s) == 8 && allDigits(s) {
+		formatter = "%Y%m%d"
+		extend = false
+	}
+	year, month, day, _, _, _, err := FormatToDateClock(s, formatter, extend)
+	if err != nil {
+		return 0, err
+	}
+	return dateDayNumber(year, month, day), nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseHolidayKind(s string) (HolidayKind, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "off", "holiday", "休", "放假":
+		return HolidayOff, true
+	case "workday", "work", "班", "补班", "上班":
+		return HolidayWorkday, true
+	}
+	return 0, false
+}
+
+//@description: 从CSV读取节假日, 每行: 日期,名称,类型 以#开头的行为注释
+//              日期: 2024-10-01, 2024/10/1 或 20241001
+//              类型: off(放假, 默认) workday(调休上班)
+//              如: 2024-10-01,National Day,off  2024-10-12,,workday
+//@param:       r io.Reader "数据"
+//@return:      *HolidaySet "节假日集合"
+//@return:      error "错误信息"
+func LoadHolidaysCSV(r io.Reader) (*HolidaySet, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	set := NewHolidaySet()
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, NewError("load holidays csv error: %v", err)
+		}
+		line, _ := reader.FieldPos(0) //记录所在的行号, 跳过的注释和空行也计数
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+		days, err := parseHolidayDate(record[0])
+		if err != nil {
+			return nil, NewError("load holidays csv error: line=%v, err=%v", line, err)
+		}
+		h := Holiday{Days: days, Kind: HolidayOff}
+		if len(record) > 1 {
+			h.Name = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			kind, ok := parseHolidayKind(record[2])
+			if !ok {
+				return nil, NewError("load holidays csv error: line=%v, unknown kind=%v", line, record[2])
+			}
+			h.Kind = kind
+		}
+		set.Add(h)
+	}
+	return set, nil
+}
+
+//iCalendar 事件
+type icsEvent struct {
+	start    int64
+	end      int64 //不含, 不大于start时为单日
+	hasStart bool
+	summary  string
+	rrule    string
+	workday  bool
+}
+
+//iCalendar 重复规则(支持的子集)
+type icsRRule struct {
+	freq     string
+	interval int
+	count    int
+	until    int64
+	hasUntil bool
+	month    int
+	nth      int //BYDAY序号, 0为不限
+	weekday  int //BYDAY星期(1-7), 0为不限
+}
+
+var icsWeekdays = map[string]int{"MO": 1, "TU": 2, "WE": 3, "TH": 4, "FR": 5, "SA": 6, "SU": 7}
+
+func parseICSRRule(s string) (rule icsRRule, err error) {
+	rule.interval = 1
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return rule, NewError("rrule error: %v", s)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		switch key {
+		case "FREQ":
+			rule.freq = value
+		case "INTERVAL":
+			if rule.interval, err = strconv.Atoi(value); err != nil || rule.interval < 1 {
+				return rule, NewError("rrule error: interval=%v", value)
+			}
+		case "COUNT":
+			if rule.count, err = strconv.Atoi(value); err != nil || rule.count < 1 {
+				return rule, NewError("rrule error: count=%v", value)
+			}
+		case "UNTIL":
+			if len(value) < 8 {
+				return rule, NewError("rrule error: until=%v", value)
+			}
+			if rule.until, err = parseHolidayDate(value[:8]); err != nil {
+				return rule, err
+			}
+			rule.hasUntil = true
+		case "BYMONTH":
+			if rule.month, err = strconv.Atoi(value); err != nil || rule.month < 1 || rule.month > 12 {
+				return rule, NewError("rrule error: bymonth=%v", value)
+			}
+		case "BYDAY":
+			if len(value) < 2 {
+				return rule, NewError("rrule error: byday=%v", value)
+			}
+			week, ok := icsWeekdays[value[len(value)-2:]]
+			if !ok {
+				return rule, NewError("rrule error: byday=%v", value)
+			}
+			rule.weekday = week
+			if len(value) > 2 {
+				if rule.nth, err = strconv.Atoi(value[:len(value)-2]); err != nil || rule.nth == 0 {
+					return rule, NewError("rrule error: byday=%v", value)
+				}
+			}
+		case "WKST":
+		default:
+			return rule, NewError("rrule error: unsupported %v", part)
+		}
+	}
+	switch rule.freq {
+	case "YEARLY", "MONTHLY":
+		if rule.weekday > 0 && rule.nth == 0 {
+			return rule, NewError("rrule error: byday without ordinal is unsupported: %v", s)
+		}
+	case "WEEKLY", "DAILY":
+		if rule.weekday > 0 || rule.month > 0 {
+			return rule, NewError("rrule error: unsupported %v", s)
+		}
+	default:
+		return rule, NewError("rrule error: freq=%v", rule.freq)
+	}
+	return rule, nil
+}
+
+//第k次重复所在的日期, 不存在时(如2月29日)返回false
+func (my *icsRRule) occurrence(start int64, k int) (int64, bool) {
+	switch my.freq {
+	case "DAILY":
+		return start + int64(k*my.interval), true
+	case "WEEKLY":
+		return start + int64(k*my.interval*7), true
+	}
+	year, month, day := dayNumberDate(start)
+	if my.freq == "YEARLY" {
+		year += k * my.interval
+		if my.month > 0 {
+			month = my.month
+		}
+	} else {
+		month += k * my.interval
+		year += (month - 1) / 12
+		month = (month-1)%12 + 1
+	}
+	if my.weekday > 0 {
+		days := nthWeekdayHoliday{month: month, n: my.nth, weekday: my.weekday}.Days(year)
+		if len(days) == 0 {
+			return 0, false
+		}
+		return days[0], true
+	}
+	if checkDateClock(year, month, day, 0, 0, 0) != nil {
+		return 0, false
+	}
+	return dateDayNumber(year, month, day), true
+}
+
+//每隔N年重复的规则
+type yearlyHolidayRule struct {
+	from  int //开始年
+	rrule icsRRule
+	start int64
+}
+
+func (my yearlyHolidayRule) Days(year int) []int64 {
+	if year < my.from || (year-my.from)%my.rrule.interval != 0 {
+		return nil
+	}
+	if days, ok := my.rrule.occurrence(my.start, (year-my.from)/my.rrule.interval); ok {
+		return []int64{days}
+	}
+	return nil
+}
+
+//icsMaxOccurrences 有限重复规则展开的最大次数
+const icsMaxOccurrences = 10000
+
+func (my *icsEvent) addTo(set *HolidaySet) error {
+	kind := HolidayOff
+	if my.workday {
+		kind = HolidayWorkday
+	}
+	length := int64(1)
+	if my.end > my.start {
+		length = my.end - my.start
+	}
+	add := func(days int64) {
+		for i := int64(0); i < length; i++ {
+			set.Add(Holiday{Days: days + i, Name: my.summary, Kind: kind})
+		}
+	}
+	if my.rrule == "" {
+		add(my.start)
+		return nil
+	}
+	rule, err := parseICSRRule(my.rrule)
+	if err != nil {
+		return err
+	}
+	if rule.count == 0 && !rule.hasUntil {
+		if rule.freq != "YEARLY" || length != 1 || my.workday {
+			return NewError("rrule error: unbounded %v", my.rrule)
+		}
+		year, _, _ := dayNumberDate(my.start)
+		set.AddRule(yearlyHolidayRule{from: year, rrule: rule, start: my.start}, my.summary)
+		return nil
+	}
+	var n int
+	for k := 0; k < icsMaxOccurrences; k++ {
+		days, ok := rule.occurrence(my.start, k)
+		if !ok {
+			continue
+		}
+		if rule.hasUntil && days > rule.until {
+			break
+		}
+		add(days)
+		n++
+		if rule.count > 0 && n >= rule.count {
+			break
+		}
+	}
+	return nil
+}
+
+//@description: 从iCalendar(.ics)读取节假日, 每个VEVENT为一个节假日
+//              支持 DTSTART, DTEND(不含), SUMMARY, RRULE(FREQ, INTERVAL, COUNT, UNTIL, BYMONTH, 带序号的BYDAY)
+//              SUMMARY 中的转义字符(\\, \;, \,, \n)会被还原
+//              CATEGORIES 含 WORKDAY 或 SUMMARY 含 "补班" 的事件为调休上班
+//@param:       r io.Reader "数据"
+//@return:      *HolidaySet "节假日集合"
+//@return:      error "错误信息"
+func LoadHolidaysICS(r io.Reader) (*HolidaySet, error) {
+	var lines []string
+	var lineNums []int //每个逻辑行开始的物理行号
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:] //折行
+			continue
+		}
+		lines = append(lines, line)
+		lineNums = append(lineNums, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, NewError("load holidays ics error: %v", err)
+	}
+
+	set := NewHolidaySet()
+	var event *icsEvent
+	for i, line := range lines {
+		lineNum := lineNums[i]
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			continue
+		}
+		name, value := strings.ToUpper(line[:colon]), line[colon+1:]
+		if semi := strings.IndexByte(name, ';'); semi >= 0 {
+			name = name[:semi]
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &icsEvent{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				return nil, NewError("load holidays ics error: line=%v, unexpected END:VEVENT", lineNum)
+			}
+			if !event.hasStart {
+				return nil, NewError("load holidays ics error: line=%v, missing DTSTART", lineNum)
+			}
+			if strings.Contains(event.summary, "补班") {
+				event.workday = true
+			}
+			if err := event.addTo(set); err != nil {
+				return nil, NewError("load holidays ics error: line=%v, err=%v", lineNum, err)
+			}
+			event = nil
+		case event == nil:
+		case name == "DTSTART" || name == "DTEND":
+			if len(value) < 8 {
+				return nil, NewError("load holidays ics error: line=%v, %v=%v", lineNum, name, value)
+			}
+			days, err := parseHolidayDate(value[:8])
+			if err != nil {
+				return nil, NewError("load holidays ics error: line=%v, err=%v", lineNum, err)
+			}
+			if name == "DTSTART" {
+				event.start = days
+				event.hasStart = true
+			} else {
+				event.end = days
+			}
+		case name == "SUMMARY":
+			event.summary = strings.TrimSpace(icsUnescape(value))
+		case name == "RRULE":
+			event.rrule = value
+		case name == "CATEGORIES":
+			for _, c := range strings.Split(value, ",") {
+				if strings.EqualFold(strings.TrimSpace(c), "WORKDAY") {
+					event.workday = true
+				}
+			}
+		}
+	}
+	return set, nil
+}
+
+//还原iCalendar文本中的转义字符: \\ \; \, \n \N
+func icsUnescape(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+//@description: 从文件读取节假日, 根据扩展名(.ics, .csv)选择格式
+//@param:       path string "文件路径"
+//@return:      *HolidaySet "节假日集合"
+//@return:      error "错误信息"
+func LoadHolidaysFile(path string) (*HolidaySet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics", ".ical":
+		return LoadHolidaysICS(f)
+	case ".csv":
+		return LoadHolidaysCSV(f)
+	}
+	return nil, NewError("load holidays file error: unknown format %v", path)
+}
@@ -0,0 +1,175 @@
+package datetime
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func holidayDate(days int64) string {
+	return time.Unix(days*daySec, 0).UTC().Format("2006-01-02")
+}
+
+func TestParseHolidayDate(t *testing.T) {
+	cases := []struct {
+		s    string
+		want string
+	}{
+		{"2024-10-01", "2024-10-01"},
+		{"20241001", "2024-10-01"},
+		{" 2024/10/1 ", "2024-10-01"},
+		{"2024/1/1", "2024-01-01"},
+		{"2024-1-1", "2024-01-01"},
+		{"2024.1.31", "2024-01-31"},
+	}
+	for _, c := range cases {
+		days, err := parseHolidayDate(c.s)
+		if err != nil || holidayDate(days) != c.want {
+			t.Errorf("parseHolidayDate(%q) = %v, %v, want %v", c.s, holidayDate(days), err, c.want)
+		}
+	}
+	for _, s := range []string{"", "20240230", "2024/2/30", "abcdefgh"} {
+		if _, err := parseHolidayDate(s); err == nil {
+			t.Errorf("parseHolidayDate(%q) error = nil", s)
+		}
+	}
+}
+
+func TestLoadHolidaysCSV(t *testing.T) {
+	data := `date,name,kind
+# 2024年国庆节
+2024-10-01,National Day,off
+2024/10/2,"National Day, 2",休
+
+20241003,,
+2024/9/29,国庆补班,workday
+2024-10-12,,班
+`
+	set, err := LoadHolidaysCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		date string
+		name string
+		kind HolidayKind
+	}{
+		{"2024-09-29", "国庆补班", HolidayWorkday},
+		{"2024-10-01", "National Day", HolidayOff},
+		{"2024-10-02", "National Day, 2", HolidayOff},
+		{"2024-10-03", "", HolidayOff},
+		{"2024-10-12", "", HolidayWorkday},
+	}
+	got := set.Holidays()
+	if len(got) != len(want) {
+		t.Fatalf("LoadHolidaysCSV() = %v holidays, want %v", len(got), len(want))
+	}
+	for i, w := range want {
+		if h := got[i]; holidayDate(h.Days) != w.date || h.Name != w.name || h.Kind != w.kind {
+			t.Errorf("holiday[%v] = %v %q %v, want %v %q %v", i, holidayDate(h.Days), h.Name, h.Kind, w.date, w.name, w.kind)
+		}
+	}
+}
+
+func TestLoadHolidaysCSVError(t *testing.T) {
+	cases := []struct {
+		data string
+		line string //错误信息中的物理行号
+	}{
+		{"2024-10-01\n2024-13-01\n", "line=2"},
+		{"# comment\n\n2024-10-01\n# comment\n2024-10-32,x\n", "line=5"},
+		{"2024-10-01,\"multi\nline\"\n2024-10-02,x,unknown\n", "line=3"},
+		{"date\n2024-10-01\n\n\nbad\n", "line=5"},
+	}
+	for _, c := range cases {
+		_, err := LoadHolidaysCSV(strings.NewReader(c.data))
+		if err == nil || !strings.Contains(err.Error(), c.line) {
+			t.Errorf("LoadHolidaysCSV(%q) error = %v, want %v", c.data, err, c.line)
+		}
+	}
+}
+
+func TestLoadHolidaysICS(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20241001",
+		"DTEND;VALUE=DATE:20241004",
+		"SUMMARY:National Day\\, Golden Week",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240929",
+		"SUMMARY:国庆补班",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20241012",
+		"SUMMARY:Make-up day\\; Saturday",
+		"CATEGORIES:HOLIDAY,WORKDAY",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20241225",
+		"SUMMARY:Christmas\\nDay \\\\ Noel",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20201126",
+		"SUMMARY:Thanks",
+		" giving",
+		"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=5",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20200101",
+		"SUMMARY:New Year",
+		"RRULE:FREQ=YEARLY",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	set, err := LoadHolidaysICS(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		date string
+		name string
+		kind HolidayKind
+	}{
+		{"2024-10-01", "National Day, Golden Week", HolidayOff},
+		{"2024-10-03", "National Day, Golden Week", HolidayOff},
+		{"2024-09-29", "国庆补班", HolidayWorkday},
+		{"2024-10-12", "Make-up day; Saturday", HolidayWorkday},
+		{"2024-12-25", "Christmas\nDay \\ Noel", HolidayOff},
+		{"2020-11-26", "Thanksgiving", HolidayOff},
+		{"2024-11-28", "Thanksgiving", HolidayOff},
+		{"2030-01-01", "New Year", HolidayOff},
+	}
+	for _, c := range cases {
+		days, _ := parseHolidayDate(c.date)
+		h, ok := set.Get(days)
+		if !ok || h.Name != c.name || h.Kind != c.kind {
+			t.Errorf("Get(%v) = %q %v %v, want %q %v", c.date, h.Name, h.Kind, ok, c.name, c.kind)
+		}
+	}
+	for _, date := range []string{"2024-10-04", "2025-11-27"} {
+		days, _ := parseHolidayDate(date)
+		if h, ok := set.Get(days); ok {
+			t.Errorf("Get(%v) = %q, want none", date, h.Name)
+		}
+	}
+}
+
+func TestLoadHolidaysICSError(t *testing.T) {
+	cases := []struct {
+		data string
+		line string
+	}{
+		{"BEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n", "line=3"},
+		{"BEGIN:VEVENT\nSUMMARY:a\n long\n folded\nDTSTART:2024\nEND:VEVENT\n", "line=5"},
+		{"BEGIN:VEVENT\nDTSTART:20240101\nRRULE:FREQ=DAILY\nEND:VEVENT\n", "line=4"},
+		{"END:VEVENT\n", "line=1"},
+	}
+	for _, c := range cases {
+		_, err := LoadHolidaysICS(strings.NewReader(c.data))
+		if err == nil || !strings.Contains(err.Error(), c.line) {
+			t.Errorf("LoadHolidaysICS(%q) error = %v, want %v", c.data, err, c.line)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package datetime
+
+import (
+	"strings"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//预定义的格式化模板
+const (
+	LayoutRFC1123 = "%a, %d %b %Y %H:%M:%S GMT" //HTTP日期(IMF-fixdate) 如: Sun, 06 Nov 1994 08:49:37 GMT
+	LayoutRFC850  = "%A, %d-%b-%y %H:%M:%S GMT" //已废弃的HTTP日期 如: Sunday, 06-Nov-94 08:49:37 GMT
+	LayoutANSIC   = "%a %b %e %H:%M:%S %Y"      //C语言asctime格式 如: Sun Nov  6 08:49:37 1994
+	LayoutRFC2822 = "%a, %d %b %Y %H:%M:%S %z"  //电子邮件日期 如: Sun, 06 Nov 1994 08:49:37 +0800
+)
+
+//RFC 2822 可省略星期和秒, 年份可以是两位数
+var rfc2822Layouts = []string{
+	"%a, %e %b %Y %H:%M:%S %z", "%a, %e %b %Y %H:%M %z", "%e %b %Y %H:%M:%S %z", "%e %b %Y %H:%M %z",
+	"%a, %e %b %y %H:%M:%S %z", "%a, %e %b %y %H:%M %z", "%e %b %y %H:%M:%S %z", "%e %b %y %H:%M %z",
+}
+
+//@description: 秒级时间戳 -> HTTP日期字符串, 总是使用GMT 如: Sun, 06 Nov 1994 08:49:37 GMT
+//@param:       unix int64 "秒级时间戳"
+//@return:      string "HTTP日期字符串"
+func FormatHTTPDate(unix int64) string {
+	year, month, day, hour, min, sec, _, _ := unixToDateClock(unix, 0)
+	return formatDateClock(year, month, day, hour, min, sec, 0, LayoutRFC1123, LocaleEnglish)
+}
+
+//@description: HTTP日期字符串 -> 秒级时间戳, 接受 RFC 9110 要求的三种格式: IMF-fixdate, RFC 850, asctime
+//              RFC 850 的两位数年份按不超过当前50年之后解释
+//@param:       s string "HTTP日期字符串"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息" 解析失败时为 *ParseError
+func ParseHTTPDate(s string) (int64, error) {
+	var firstErr error
+	for _, layout := range []string{LayoutRFC1123, LayoutRFC850, LayoutANSIC} {
+		parser := dateClockParser{s: s, layout: layout}
+		year, month, day, hour, min, sec, err := parser.parse(Local())
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if layout == LayoutRFC850 {
+			current, _, _, _, _, _, _, _ := unixToDateClock(Unix(), 0)
+			year = int(floorDiv(int64(current), 100))*100 + year%100
+			if year > current+50 {
+				year -= 100
+			}
+		}
+		unix, _, _, err := dateClockToUnix(year, month, day, hour, min, sec, 0)
+		if err != nil {
+			return 0, parser.rangeError(err)
+		}
+		return unix, nil
+	}
+	return 0, firstErr
+}
+
+//@description: 秒级时间戳 -> RFC 2822 电子邮件日期字符串 如: Sun, 06 Nov 1994 08:49:37 +0800
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      string "日期字符串"
+func FormatRFC2822(unix int64, zone TimeZone) string {
+	return UnixToFormatLocale(unix, zone, LayoutRFC2822, LocaleEnglish)
+}
+
+//@description: RFC 2822 电子邮件日期字符串 -> 秒级时间戳
+//              星期和秒可省略, 接受数字时区偏移和已废弃的时区名称(UT, GMT, EST, PDT等), 忽略末尾的注释 如: (CST)
+//              两位数年份: 00-49为20xx, 50-99为19xx
+//@param:       s string "日期字符串"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息" 解析失败时为 *ParseError
+func ParseRFC2822(s string) (int64, error) {
+	input := strings.TrimSpace(s)
+	if strings.HasSuffix(input, ")") {
+		if i := strings.LastIndexByte(input, '('); i > 0 {
+			input = input[:i]
+		}
+	}
+	opts := ParseOptions{TrimSpace: true, IgnoreCase: true}
+	var firstErr error
+	for _, layout := range rfc2822Layouts {
+		parser := dateClockParser{s: input, layout: layout, opts: opts}
+		year, month, day, hour, min, sec, err := parser.parse(Local())
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if strings.Contains(layout, "%y") {
+			year = year % 100
+			if year < 50 {
+				year += 2000
+			} else {
+				year += 1900
+			}
+		}
+		unix, _, _, err := dateClockToUnix(year, month, day, hour, min, sec, parser.offset)
+		if err != nil {
+			pErr := parser.rangeError(err)
+			pErr.Input = s
+			return 0, pErr
+		}
+		return unix, nil
+	}
+	if pErr, ok := firstErr.(*ParseError); ok {
+		pErr.Input = s
+	}
+	return 0, firstErr
+}
+
+//@description: 返回HTTP日期字符串(GMT)
+//@return:      string "HTTP日期字符串"
+func (my *DateTime) HTTPDate() string {
+	return FormatHTTPDate(my.unix)
+}
+
+//@description: 返回RFC 2822 电子邮件日期字符串
+//@return:      string "日期字符串"
+func (my *DateTime) RFC2822() string {
+	return FormatRFC2822(my.unix, my.zone)
+}
@@ -0,0 +1,28 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHTTPDateRangeError(t *testing.T) {
+	cases := []struct {
+		name  string
+		parse func(string) (int64, error)
+		s     string
+	}{
+		{"ParseHTTPDate", ParseHTTPDate, "Fri, 31 Dec 292277026596 00:00:00 GMT"},
+		{"ParseRFC2822", ParseRFC2822, "Fri, 31 Dec 292277026596 00:00:00 +0800"},
+		{"ParseRFC2822", ParseRFC2822, " 31 Dec 292277026596 00:00 GMT (comment)"},
+	}
+	for _, c := range cases {
+		_, err := c.parse(c.s)
+		var pErr *ParseError
+		if !errors.As(err, &pErr) || !errors.Is(err, ErrRange) || pErr.Input != c.s || pErr.Layout == "" {
+			t.Errorf("%v(%q) error = %#v, want *ParseError with ErrRange", c.name, c.s, err)
+		}
+	}
+	if unix, err := ParseHTTPDate("Sun, 06 Nov 1994 08:49:37 GMT"); err != nil || unix != 784111777 {
+		t.Errorf("ParseHTTPDate = %v, %v, want 784111777", unix, err)
+	}
+}
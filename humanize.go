@@ -0,0 +1,158 @@
+package datetime
+
+import (
+	"fmt"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//相对时间的最小显示单位
+type HumanizeUnit int
+
+const (
+	HumanizeSecond HumanizeUnit = iota //秒 如: 30秒前
+	HumanizeMinute                     //分钟 如: 3分钟前
+	HumanizeHour                       //小时 如: 2小时前
+	HumanizeDay                        //天 如: 今天, 昨天
+)
+
+//相对时间的显示选项
+//按相差的时间依次显示: 刚刚 -> x分钟前/x小时前 -> 今天/昨天/明天 -> 星期名称/x天前 -> 完整日期
+type HumanizeOptions struct {
+	Locale      *Locale      //语言环境, nil为英文
+	Granularity HumanizeUnit //最小显示单位, 为 HumanizeDay 时不显示 x分钟前, x小时前
+	JustNow     int64        //相差秒数小于该值时显示"刚刚"
+	Relative    int64        //相差秒数小于该值时显示 x分钟前, x小时前, 否则按日历天数显示 不大于0时使用 DefaultHumanize.Relative
+	Weekday     bool         //相差7天以内时显示星期名称 如: Monday, 否则显示 x天前
+	Days        int64        //相差日历天数小于该值时显示 今天, 昨天, 星期名称, x天前, 否则显示完整日期 不大于0时使用 DefaultHumanize.Days
+	Layout      string       //完整日期的格式化模板 如: "%Y-%m-%d" 为空时使用 DefaultHumanize.Layout
+}
+
+//默认的显示选项: 45秒内为刚刚, 24小时内显示分钟和小时, 7天内显示星期名称
+var DefaultHumanize = HumanizeOptions{
+	Granularity: HumanizeMinute,
+	JustNow:     45,
+	Relative:    daySec,
+	Weekday:     true,
+	Days:        7,
+	Layout:      "%Y-%m-%d",
+}
+
+//相对时间 如: 3 minutes ago, in 2 days, 3分钟前, 2天后
+func humanizeRelative(n int64, unit HumanizeUnit, past bool, locale *Locale) string {
+	form := locale.Units[unit][1]
+	if n == 1 {
+		form = locale.Units[unit][0]
+	}
+	s := fmt.Sprintf(form, n)
+	if past {
+		return fmt.Sprintf(locale.Ago, s)
+	}
+	return fmt.Sprintf(locale.Later, s)
+}
+
+//@description: 秒级时间戳 from 相对于 to 的友好时间描述, 使用指定选项
+//@param:       from int64 "要描述的秒级时间戳"
+//@param:       to int64 "参照的秒级时间戳" 一般为当前时间
+//@param:       zone TimeZone "时区" 用于判断今天, 昨天, 星期和格式化完整日期
+//@param:       opts HumanizeOptions "显示选项"
+//@return:      string "友好时间描述" 如: just now, 3 minutes ago, yesterday, Monday, 2020-09-12
+func HumanizeUnixOptions(from, to int64, zone TimeZone, opts HumanizeOptions) string {
+	locale := opts.Locale
+	if locale == nil {
+		locale = LocaleEnglish
+	}
+	if opts.Relative <= 0 {
+		opts.Relative = DefaultHumanize.Relative
+	}
+	if opts.Days <= 0 {
+		opts.Days = DefaultHumanize.Days
+	}
+	if opts.Layout == "" {
+		opts.Layout = DefaultHumanize.Layout
+	}
+	diff := to - from
+	past := diff >= 0
+	if !past {
+		diff = -diff
+	}
+	if diff < opts.JustNow {
+		return locale.JustNow
+	}
+	if diff < opts.Relative && opts.Granularity < HumanizeDay {
+		switch {
+		case diff < minSec && opts.Granularity == HumanizeSecond:
+			return humanizeRelative(diff, HumanizeSecond, past, locale)
+		case diff < hourSec && opts.Granularity <= HumanizeMinute:
+			if diff < minSec {
+				return humanizeRelative(1, HumanizeMinute, past, locale)
+			}
+			return humanizeRelative(diff/minSec, HumanizeMinute, past, locale)
+		case diff < hourSec:
+			return humanizeRelative(1, HumanizeHour, past, locale)
+		}
+		return humanizeRelative(diff/hourSec, HumanizeHour, past, locale)
+	}
+
+	fromDays := localDayNumber(from, zone)
+	days := localDayNumber(to, zone) - fromDays
+	if days < 0 {
+		days = -days
+	}
+	if days < opts.Days {
+		switch {
+		case days == 0:
+			return locale.Today
+		case days == 1 && past:
+			return locale.Yesterday
+		case days == 1:
+			return locale.Tomorrow
+		case days < 7 && opts.Weekday:
+			return locale.Weekdays[dayNumberWeekdayA(fromDays)-1]
+		}
+		return humanizeRelative(days, HumanizeDay, past, locale)
+	}
+	return UnixToFormatLocale(from, zone, opts.Layout, locale)
+}
+
+//@description: 秒级时间戳 from 相对于 to 的友好时间描述, 使用默认选项和英文
+//@param:       from int64 "要描述的秒级时间戳"
+//@param:       to int64 "参照的秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      string "友好时间描述"
+func HumanizeUnix(from, to int64, zone TimeZone) string {
+	return HumanizeUnixOptions(from, to, zone, DefaultHumanize)
+}
+
+//@description: from 相对于 to 的友好时间描述, 使用默认选项和英文, 按 to 的时区判断日历天数
+//@param:       from *DateTime "要描述的时间"
+//@param:       to *DateTime "参照的时间" 一般为当前时间
+//@return:      string "友好时间描述" 如: just now, 3 minutes ago, in 2 hours, yesterday
+func Humanize(from, to *DateTime) string {
+	return HumanizeUnixOptions(from.unix, to.unix, to.zone, DefaultHumanize)
+}
+
+//@description: 相对于 now 的友好时间描述, 使用默认选项和英文
+//@param:       now *DateTime "参照的时间" 一般为当前时间
+//@return:      string "友好时间描述" 如: 3 minutes ago, yesterday
+func (my *DateTime) Since(now *DateTime) string {
+	return HumanizeUnixOptions(my.unix, now.unix, now.zone, DefaultHumanize)
+}
+
+//@description: 相对于 now 的友好时间描述 如: 3分钟前, 昨天
+//@param:       now *DateTime "参照的时间"
+//@param:       locale *Locale "语言环境" nil为英文
+//@return:      string "友好时间描述"
+func (my *DateTime) SinceLocale(now *DateTime, locale *Locale) string {
+	opts := DefaultHumanize
+	opts.Locale = locale
+	return HumanizeUnixOptions(my.unix, now.unix, now.zone, opts)
+}
+
+//@description: 相对于 now 的友好时间描述, 使用指定选项
+//@param:       now *DateTime "参照的时间"
+//@param:       opts HumanizeOptions "显示选项"
+//@return:      string "友好时间描述"
+func (my *DateTime) SinceOptions(now *DateTime, opts HumanizeOptions) string {
+	return HumanizeUnixOptions(my.unix, now.unix, now.zone, opts)
+}
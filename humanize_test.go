@@ -0,0 +1,93 @@
+package datetime
+
+import (
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestHumanizeUnix(t *testing.T) {
+	//参照时间: 2020-09-16 14:30:15 星期三(北京时间)
+	to := int64(relativeRef)
+	cases := []struct {
+		diff    int64 //to - from
+		english string
+		chinese string
+	}{
+		{0, "just now", "刚刚"},
+		{44, "just now", "刚刚"},
+		{-44, "just now", "刚刚"},
+		{45, "1 minute ago", "1分钟前"},
+		{-45, "in 1 minute", "1分钟后"},
+		{119, "1 minute ago", "1分钟前"},
+		{120, "2 minutes ago", "2分钟前"},
+		{hourSec - 1, "59 minutes ago", "59分钟前"},
+		{hourSec, "1 hour ago", "1小时前"},
+		{-3 * hourSec, "in 3 hours", "3小时后"},
+		{daySec - 1, "23 hours ago", "23小时前"},
+		{daySec, "yesterday", "昨天"},
+		{-daySec, "tomorrow", "明天"},
+		{2 * daySec, "Monday", "星期一"},
+		{-2 * daySec, "Friday", "星期五"},
+		{6 * daySec, "Thursday", "星期四"},
+		{7 * daySec, "2020-09-09", "2020-09-09"},
+		{-7 * daySec, "2020-09-23", "2020-09-23"},
+		{400 * daySec, "2019-08-13", "2019-08-13"},
+	}
+	chinese := DefaultHumanize
+	chinese.Locale = LocaleChinese
+	for _, c := range cases {
+		from := to - c.diff
+		if s := HumanizeUnix(from, to, Zones.E8); s != c.english {
+			t.Errorf("HumanizeUnix(%v) = %q, want %q", c.diff, s, c.english)
+		}
+		if s := HumanizeUnixOptions(from, to, Zones.E8, chinese); s != c.chinese {
+			t.Errorf("HumanizeUnixOptions(%v, chinese) = %q, want %q", c.diff, s, c.chinese)
+		}
+	}
+}
+
+func TestHumanizeUnixOptions(t *testing.T) {
+	to := int64(relativeRef)
+	//北京时间当天0时
+	midnight := to - (14*hourSec + 30*minSec + 15)
+	cases := []struct {
+		from int64
+		opts HumanizeOptions
+		want string
+	}{
+		{to - 30, HumanizeOptions{Granularity: HumanizeSecond}, "30 seconds ago"},
+		{to - 1, HumanizeOptions{Granularity: HumanizeSecond}, "1 second ago"},
+		{to + 90, HumanizeOptions{Granularity: HumanizeHour}, "in 1 hour"},
+		{to - 2*hourSec, HumanizeOptions{Granularity: HumanizeDay}, "today"},
+		{midnight - 1, HumanizeOptions{Granularity: HumanizeDay}, "yesterday"},
+		{midnight - 1, HumanizeOptions{Granularity: HumanizeMinute, Relative: hourSec}, "yesterday"},
+		{to - 3*daySec, HumanizeOptions{}, "3 days ago"},
+		{to + 3*daySec, HumanizeOptions{Locale: LocaleChinese}, "3天后"},
+		{to - 3*daySec, HumanizeOptions{Weekday: true}, "Sunday"},
+		{to - 10*daySec, HumanizeOptions{Days: 30}, "10 days ago"},
+		{to - 10*daySec, HumanizeOptions{Layout: "%d/%m/%Y"}, "06/09/2020"},
+		{to - 10*daySec, HumanizeOptions{Locale: LocaleChinese, Layout: "%m月%d日 %A"}, "09月06日 星期日"},
+	}
+	for _, c := range cases {
+		if s := HumanizeUnixOptions(c.from, to, Zones.E8, c.opts); s != c.want {
+			t.Errorf("HumanizeUnixOptions(%v, %+v) = %q, want %q", c.from-to, c.opts, s, c.want)
+		}
+	}
+	//零值选项使用默认的 Relative, Days, Layout
+	zero := []struct {
+		diff int64
+		want string
+	}{
+		{0, "0 seconds ago"},
+		{90 * minSec, "1 hour ago"},
+		{daySec, "yesterday"},
+		{2 * daySec, "2 days ago"},
+		{7 * daySec, "2020-09-09"},
+	}
+	for _, c := range zero {
+		if s := HumanizeUnixOptions(to-c.diff, to, Zones.E8, HumanizeOptions{}); s != c.want {
+			t.Errorf("HumanizeUnixOptions(%v, zero) = %q, want %q", c.diff, s, c.want)
+		}
+	}
+}
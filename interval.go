@@ -0,0 +1,341 @@
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//左闭右开的时间区间 [Start, End), 秒级时间戳
+type Interval struct {
+	Start int64 //开始(含)
+	End   int64 //结束(不含)
+}
+
+//@description: 创建时间区间
+//@param:       start, end int64 "开始(含),结束(不含)的秒级时间戳"
+//@return:      Interval "时间区间"
+//@return:      error "错误信息" 结束在开始之前时为 *RangeError
+func NewInterval(start, end int64) (Interval, error) {
+	if end < start {
+		return Interval{}, newRangeError("end", end, start, math.MaxInt64)
+	}
+	return Interval{Start: start, End: end}, nil
+}
+
+//@description: 由两个 DateTime 创建时间区间
+//@param:       start, end *DateTime "开始(含),结束(不含)"
+//@return:      Interval "时间区间"
+//@return:      error "错误信息"
+func DateTimeInterval(start, end *DateTime) (Interval, error) {
+	return NewInterval(start.unix, end.unix)
+}
+
+//@description: 是否为空区间
+//@return:      bool "是否为空"
+func (my Interval) IsEmpty() bool {
+	return my.End <= my.Start
+}
+
+//@description: 时长(秒)
+//@return:      int64 "秒数"
+func (my Interval) Duration() int64 {
+	if my.IsEmpty() {
+		return 0
+	}
+	return my.End - my.Start
+}
+
+//@description: 是否包含时间戳
+//@param:       unix int64 "秒级时间戳"
+//@return:      bool "是否包含"
+func (my Interval) Contains(unix int64) bool {
+	return my.Start <= unix && unix < my.End
+}
+
+//@description: 是否完全包含另一个区间, 空区间被任何区间包含
+//@param:       other Interval "时间区间"
+//@return:      bool "是否包含"
+func (my Interval) ContainsInterval(other Interval) bool {
+	return other.IsEmpty() || (my.Start <= other.Start && other.End <= my.End)
+}
+
+//@description: 是否重叠, 首尾相接不算重叠
+//@param:       other Interval "时间区间"
+//@return:      bool "是否重叠"
+func (my Interval) Overlaps(other Interval) bool {
+	return my.Start < other.End && other.Start < my.End && !my.IsEmpty() && !other.IsEmpty()
+}
+
+//@description: 交集
+//@param:       other Interval "时间区间"
+//@return:      Interval "交集"
+//@return:      bool "是否重叠" 不重叠时交集无意义
+func (my Interval) Intersect(other Interval) (Interval, bool) {
+	if !my.Overlaps(other) {
+		return Interval{}, false
+	}
+	return Interval{Start: maxInt64(my.Start, other.Start), End: minInt64(my.End, other.End)}, true
+}
+
+//@description: 并集, 只有重叠或首尾相接时才能合并为一个区间
+//@param:       other Interval "时间区间"
+//@return:      Interval "并集"
+//@return:      bool "是否能合并"
+func (my Interval) Union(other Interval) (Interval, bool) {
+	switch {
+	case other.IsEmpty():
+		return my, true
+	case my.IsEmpty():
+		return other, true
+	case my.Start > other.End || other.Start > my.End:
+		return Interval{}, false
+	}
+	return Interval{Start: minInt64(my.Start, other.Start), End: maxInt64(my.End, other.End)}, true
+}
+
+//@description: 两个区间之间的间隔
+//@param:       other Interval "时间区间"
+//@return:      Interval "间隔"
+//@return:      bool "是否有间隔" 重叠或首尾相接时没有间隔
+func (my Interval) Gap(other Interval) (Interval, bool) {
+	switch {
+	case my.IsEmpty() || other.IsEmpty():
+		return Interval{}, false
+	case my.End < other.Start:
+		return Interval{Start: my.End, End: other.Start}, true
+	case other.End < my.Start:
+		return Interval{Start: other.End, End: my.Start}, true
+	}
+	return Interval{}, false
+}
+
+//@description: 按时间单位的边界切分 如: 按天切分时每段不跨越0时
+//@param:       unit Unit "时间单位" 周以星期1为开始
+//@param:       zone TimeZone "时区"
+//@return:      []Interval "切分后的区间"
+//@return:      error "错误信息"
+func (my Interval) Split(unit Unit, zone TimeZone) ([]Interval, error) {
+	var parts []Interval
+	for start := my.Start; start < my.End; {
+		end, err := UnixEndOf(start, unit, zone)
+		if err != nil {
+			return nil, err
+		}
+		end = minInt64(end+1, my.End)
+		parts = append(parts, Interval{Start: start, End: end})
+		start = end
+	}
+	return parts, nil
+}
+
+//@description: 返回 [start, end) 形式的字符串
+func (my Interval) String() string {
+	return fmt.Sprintf("[%v, %v)", my.Start, my.End)
+}
+
+//@description: 按时区格式化
+//@param:       zone TimeZone "时区"
+//@param:       formatter string "格式化模板"
+//@return:      string "如: [2020-09-12 00:00:00, 2020-09-13 00:00:00)"
+func (my Interval) Format(zone TimeZone, formatter string) string {
+	return "[" + UnixToFormat(my.Start, zone, formatter) + ", " + UnixToFormat(my.End, zone, formatter) + ")"
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+//时间区间集合, 内部保持有序且互不重叠, 重叠或首尾相接的区间被合并
+type IntervalSet struct {
+	intervals []Interval
+}
+
+//@description: 创建时间区间集合
+//@param:       intervals ...Interval "时间区间" 可以无序和重叠
+//@return:      *IntervalSet "时间区间集合"
+func NewIntervalSet(intervals ...Interval) *IntervalSet {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, iv := range intervals {
+		if !iv.IsEmpty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+	merged := sorted[:0]
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 && iv.Start <= merged[n-1].End {
+			merged[n-1].End = maxInt64(merged[n-1].End, iv.End)
+		} else {
+			merged = append(merged, iv)
+		}
+	}
+	return &IntervalSet{intervals: merged}
+}
+
+//@description: 有序且互不重叠的区间(副本)
+//@return:      []Interval "时间区间"
+func (my *IntervalSet) Intervals() []Interval {
+	return append([]Interval(nil), my.intervals...)
+}
+
+//@description: 区间个数
+//@return:      int "个数"
+func (my *IntervalSet) Len() int {
+	return len(my.intervals)
+}
+
+//@description: 总时长(秒)
+//@return:      int64 "秒数"
+func (my *IntervalSet) Duration() int64 {
+	var total int64
+	for _, iv := range my.intervals {
+		total += iv.End - iv.Start
+	}
+	return total
+}
+
+//@description: 加入区间, 与重叠或首尾相接的区间合并
+//@param:       iv Interval "时间区间"
+func (my *IntervalSet) Add(iv Interval) {
+	if iv.IsEmpty() {
+		return
+	}
+	ivs := my.intervals
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].End >= iv.Start })
+	j := sort.Search(len(ivs), func(k int) bool { return ivs[k].Start > iv.End })
+	if i < j {
+		iv.Start = minInt64(iv.Start, ivs[i].Start)
+		iv.End = maxInt64(iv.End, ivs[j-1].End)
+	}
+	my.replace(i, j, iv)
+}
+
+//@description: 移除区间覆盖的部分
+//@param:       iv Interval "时间区间"
+func (my *IntervalSet) Remove(iv Interval) {
+	if iv.IsEmpty() {
+		return
+	}
+	ivs := my.intervals
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].End > iv.Start })
+	j := sort.Search(len(ivs), func(k int) bool { return ivs[k].Start >= iv.End })
+	if i >= j {
+		return
+	}
+	var pieces []Interval
+	if ivs[i].Start < iv.Start {
+		pieces = append(pieces, Interval{Start: ivs[i].Start, End: iv.Start})
+	}
+	if ivs[j-1].End > iv.End {
+		pieces = append(pieces, Interval{Start: iv.End, End: ivs[j-1].End})
+	}
+	my.replace(i, j, pieces...)
+}
+
+//用 pieces 替换 [i, j) 的区间
+func (my *IntervalSet) replace(i, j int, pieces ...Interval) {
+	tail := append(pieces, my.intervals[j:]...)
+	my.intervals = append(my.intervals[:i], tail...)
+}
+
+//@description: 是否包含时间戳
+//@param:       unix int64 "秒级时间戳"
+//@return:      bool "是否包含"
+func (my *IntervalSet) Contains(unix int64) bool {
+	ivs := my.intervals
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].End > unix })
+	return i < len(ivs) && ivs[i].Start <= unix
+}
+
+//@description: 是否完全包含区间
+//@param:       iv Interval "时间区间"
+//@return:      bool "是否包含"
+func (my *IntervalSet) ContainsInterval(iv Interval) bool {
+	if iv.IsEmpty() {
+		return true
+	}
+	ivs := my.intervals
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].End > iv.Start })
+	return i < len(ivs) && ivs[i].ContainsInterval(iv)
+}
+
+//@description: 是否与区间重叠
+//@param:       iv Interval "时间区间"
+//@return:      bool "是否重叠"
+func (my *IntervalSet) Overlaps(iv Interval) bool {
+	ivs := my.intervals
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].End > iv.Start })
+	return i < len(ivs) && ivs[i].Overlaps(iv)
+}
+
+//@description: 并集
+//@param:       other *IntervalSet "时间区间集合"
+//@return:      *IntervalSet "新的集合"
+func (my *IntervalSet) Union(other *IntervalSet) *IntervalSet {
+	return NewIntervalSet(append(my.Intervals(), other.intervals...)...)
+}
+
+//@description: 交集
+//@param:       other *IntervalSet "时间区间集合"
+//@return:      *IntervalSet "新的集合"
+func (my *IntervalSet) Intersect(other *IntervalSet) *IntervalSet {
+	var result []Interval
+	a, b := my.intervals, other.intervals
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if iv, ok := a[i].Intersect(b[j]); ok {
+			result = append(result, iv)
+		}
+		if a[i].End < b[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return &IntervalSet{intervals: result}
+}
+
+//@description: 差集, 移除other覆盖的部分
+//@param:       other *IntervalSet "时间区间集合"
+//@return:      *IntervalSet "新的集合"
+func (my *IntervalSet) Subtract(other *IntervalSet) *IntervalSet {
+	result := &IntervalSet{intervals: my.Intervals()}
+	for _, iv := range other.intervals {
+		result.Remove(iv)
+	}
+	return result
+}
+
+//@description: 区间内没有被覆盖的部分 如: 空闲时段
+//@param:       within Interval "时间区间"
+//@return:      []Interval "未被覆盖的区间"
+func (my *IntervalSet) Gaps(within Interval) []Interval {
+	gaps := NewIntervalSet(within)
+	return gaps.Subtract(my).intervals
+}
+
+//@description: 返回 [a, b) [c, d) 形式的字符串
+func (my *IntervalSet) String() string {
+	s := ""
+	for i, iv := range my.intervals {
+		if i > 0 {
+			s += " "
+		}
+		s += iv.String()
+	}
+	return s
+}
@@ -0,0 +1,212 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestInterval(t *testing.T) {
+	if _, err := NewInterval(2, 1); !errors.Is(err, ErrRange) {
+		t.Errorf("NewInterval(2, 1) error = %v", err)
+	}
+	if iv, err := NewInterval(1, 1); err != nil || !iv.IsEmpty() || iv.Duration() != 0 {
+		t.Errorf("NewInterval(1, 1) = %v, %v", iv, err)
+	}
+	a, b := Interval{0, 10}, Interval{10, 20}
+	if a.Overlaps(b) || a.Contains(10) || !a.Contains(0) {
+		t.Errorf("touching intervals: Overlaps = %v, Contains(10) = %v", a.Overlaps(b), a.Contains(10))
+	}
+	if iv, ok := a.Union(b); !ok || iv != (Interval{0, 20}) {
+		t.Errorf("Union = %v, %v", iv, ok)
+	}
+	if _, ok := a.Gap(b); ok {
+		t.Errorf("touching intervals have a gap")
+	}
+	if _, ok := a.Intersect(b); ok {
+		t.Errorf("touching intervals intersect")
+	}
+	if iv, ok := a.Gap(Interval{15, 20}); !ok || iv != (Interval{10, 15}) {
+		t.Errorf("Gap = %v, %v", iv, ok)
+	}
+	if iv, ok := a.Intersect(Interval{5, 15}); !ok || iv != (Interval{5, 10}) {
+		t.Errorf("Intersect = %v, %v", iv, ok)
+	}
+	empty := Interval{5, 5}
+	if a.Overlaps(empty) || !a.ContainsInterval(empty) || !b.ContainsInterval(empty) {
+		t.Errorf("empty interval: Overlaps = %v, ContainsInterval = %v", a.Overlaps(empty), a.ContainsInterval(empty))
+	}
+	if iv, ok := empty.Union(b); !ok || iv != b {
+		t.Errorf("empty Union = %v, %v", iv, ok)
+	}
+}
+
+func TestIntervalSplit(t *testing.T) {
+	//2020-09-16 00:00:00 星期三(北京时间)
+	const b = relativeRef - 14*hourSec - 30*minSec - 15
+	cases := []struct {
+		iv   Interval
+		unit Unit
+		want []Interval
+	}{
+		{Interval{b + 30*minSec, b + 2*hourSec + 10*minSec}, UnitHour,
+			[]Interval{{b + 30*minSec, b + hourSec}, {b + hourSec, b + 2*hourSec}, {b + 2*hourSec, b + 2*hourSec + 10*minSec}}},
+		{Interval{b - 2*hourSec, b + 26*hourSec}, UnitDay,
+			[]Interval{{b - 2*hourSec, b}, {b, b + daySec}, {b + daySec, b + 26*hourSec}}},
+		{Interval{b, b + daySec}, UnitDay, []Interval{{b, b + daySec}}},
+		{Interval{b, b + 7*daySec}, UnitWeek, []Interval{{b, b + 5*daySec}, {b + 5*daySec, b + 7*daySec}}},
+		{Interval{b, b + 30*daySec}, UnitMonth, []Interval{{b, b + 15*daySec}, {b + 15*daySec, b + 30*daySec}}},
+		{Interval{b, b}, UnitDay, nil},
+		{Interval{b + 1, b}, UnitDay, nil},
+	}
+	for _, c := range cases {
+		parts, err := c.iv.Split(c.unit, Zones.E8)
+		if err != nil {
+			t.Errorf("%v.Split(%v) error: %v", c.iv, c.unit, err)
+			continue
+		}
+		if fmt.Sprint(parts) != fmt.Sprint(c.want) {
+			t.Errorf("%v.Split(%v) = %v, want %v", c.iv, c.unit, parts, c.want)
+		}
+	}
+}
+
+func TestIntervalSetAddRemove(t *testing.T) {
+	base := []Interval{{50, 60}, {10, 20}, {30, 40}, {35, 38}, {0, 0}}
+	if s := NewIntervalSet(base...).String(); s != "[10, 20) [30, 40) [50, 60)" {
+		t.Fatalf("NewIntervalSet = %s", s)
+	}
+	adds := []struct {
+		iv   Interval
+		want string
+	}{
+		{Interval{0, 5}, "[0, 5) [10, 20) [30, 40) [50, 60)"},
+		{Interval{5, 10}, "[5, 20) [30, 40) [50, 60)"},
+		{Interval{20, 30}, "[10, 40) [50, 60)"},
+		{Interval{15, 55}, "[10, 60)"},
+		{Interval{25, 27}, "[10, 20) [25, 27) [30, 40) [50, 60)"},
+		{Interval{12, 18}, "[10, 20) [30, 40) [50, 60)"},
+		{Interval{60, 70}, "[10, 20) [30, 40) [50, 70)"},
+		{Interval{70, 80}, "[10, 20) [30, 40) [50, 60) [70, 80)"},
+		{Interval{0, 100}, "[0, 100)"},
+		{Interval{5, 5}, "[10, 20) [30, 40) [50, 60)"},
+		{Interval{25, 15}, "[10, 20) [30, 40) [50, 60)"},
+	}
+	for _, c := range adds {
+		set := NewIntervalSet(base...)
+		before := set.Intervals()
+		set.Add(c.iv)
+		if s := set.String(); s != c.want {
+			t.Errorf("Add(%v) = %s, want %s", c.iv, s, c.want)
+		}
+		if s := NewIntervalSet(before...).String(); s != "[10, 20) [30, 40) [50, 60)" {
+			t.Errorf("Add(%v) modified the copy from Intervals(): %s", c.iv, s)
+		}
+	}
+	removes := []struct {
+		iv   Interval
+		want string
+	}{
+		{Interval{15, 35}, "[10, 15) [35, 40) [50, 60)"},
+		{Interval{10, 20}, "[30, 40) [50, 60)"},
+		{Interval{12, 18}, "[10, 12) [18, 20) [30, 40) [50, 60)"},
+		{Interval{35, 55}, "[10, 20) [30, 35) [55, 60)"},
+		{Interval{20, 30}, "[10, 20) [30, 40) [50, 60)"},
+		{Interval{40, 50}, "[10, 20) [30, 40) [50, 60)"},
+		{Interval{60, 70}, "[10, 20) [30, 40) [50, 60)"},
+		{Interval{0, 100}, ""},
+		{Interval{5, 5}, "[10, 20) [30, 40) [50, 60)"},
+	}
+	for _, c := range removes {
+		set := NewIntervalSet(base...)
+		set.Remove(c.iv)
+		if s := set.String(); s != c.want {
+			t.Errorf("Remove(%v) = %s, want %s", c.iv, s, c.want)
+		}
+	}
+	//连续修改同一个集合
+	set := NewIntervalSet()
+	set.Add(Interval{0, 10})
+	set.Add(Interval{20, 30})
+	set.Remove(Interval{5, 25})
+	set.Add(Interval{40, 50})
+	set.Add(Interval{10, 20})
+	set.Remove(Interval{45, 46})
+	if s := set.String(); s != "[0, 5) [10, 20) [25, 30) [40, 45) [46, 50)" {
+		t.Errorf("sequence = %s", s)
+	}
+	if set.Len() != 5 || set.Duration() != 29 {
+		t.Errorf("Len = %d, Duration = %d", set.Len(), set.Duration())
+	}
+	if !set.Contains(0) || set.Contains(5) || !set.Contains(19) || set.Contains(20) || set.Contains(45) {
+		t.Errorf("Contains mismatch: %s", set)
+	}
+	if !set.ContainsInterval(Interval{10, 20}) || set.ContainsInterval(Interval{0, 10}) || !set.ContainsInterval(Interval{7, 7}) {
+		t.Errorf("ContainsInterval mismatch: %s", set)
+	}
+	if set.Overlaps(Interval{5, 10}) || !set.Overlaps(Interval{4, 10}) || set.Overlaps(Interval{45, 46}) {
+		t.Errorf("Overlaps mismatch: %s", set)
+	}
+}
+
+func TestIntervalSetOperations(t *testing.T) {
+	cases := []struct {
+		a, b      []Interval
+		union     string
+		intersect string
+		subtract  string
+	}{
+		{[]Interval{{0, 10}, {20, 30}}, []Interval{{5, 25}}, "[0, 30)", "[5, 10) [20, 25)", "[0, 5) [25, 30)"},
+		{[]Interval{{0, 10}}, []Interval{{10, 20}}, "[0, 20)", "", "[0, 10)"},
+		{[]Interval{{0, 10}, {20, 30}}, []Interval{{10, 20}}, "[0, 30)", "", "[0, 10) [20, 30)"},
+		{[]Interval{{0, 10}}, nil, "[0, 10)", "", "[0, 10)"},
+		{nil, []Interval{{0, 10}}, "[0, 10)", "", ""},
+		{[]Interval{{0, 100}}, []Interval{{10, 20}, {30, 40}}, "[0, 100)", "[10, 20) [30, 40)", "[0, 10) [20, 30) [40, 100)"},
+		{[]Interval{{0, 5}, {8, 12}, {15, 30}}, []Interval{{3, 9}, {11, 16}, {29, 40}},
+			"[0, 40)", "[3, 5) [8, 9) [11, 12) [15, 16) [29, 30)", "[0, 3) [9, 11) [16, 29)"},
+	}
+	for _, c := range cases {
+		a, b := NewIntervalSet(c.a...), NewIntervalSet(c.b...)
+		if s := a.Union(b).String(); s != c.union {
+			t.Errorf("%s Union %s = %s, want %s", a, b, s, c.union)
+		}
+		if s := a.Intersect(b).String(); s != c.intersect {
+			t.Errorf("%s Intersect %s = %s, want %s", a, b, s, c.intersect)
+		}
+		if s := b.Intersect(a).String(); s != c.intersect {
+			t.Errorf("%s Intersect %s = %s, want %s", b, a, s, c.intersect)
+		}
+		if s := a.Subtract(b).String(); s != c.subtract {
+			t.Errorf("%s Subtract %s = %s, want %s", a, b, s, c.subtract)
+		}
+		if s := NewIntervalSet(c.a...).String(); s != a.String() {
+			t.Errorf("operations modified %s", s)
+		}
+	}
+}
+
+func TestIntervalSetGaps(t *testing.T) {
+	set := NewIntervalSet(Interval{10, 20}, Interval{30, 40})
+	cases := []struct {
+		within Interval
+		want   []Interval
+	}{
+		{Interval{0, 50}, []Interval{{0, 10}, {20, 30}, {40, 50}}},
+		{Interval{10, 40}, []Interval{{20, 30}}},
+		{Interval{20, 30}, []Interval{{20, 30}}},
+		{Interval{15, 35}, []Interval{{20, 30}}},
+		{Interval{12, 18}, nil},
+		{Interval{5, 5}, nil},
+		{Interval{50, 60}, []Interval{{50, 60}}},
+	}
+	for _, c := range cases {
+		if gaps := set.Gaps(c.within); fmt.Sprint(gaps) != fmt.Sprint(c.want) {
+			t.Errorf("Gaps(%v) = %v, want %v", c.within, gaps, c.want)
+		}
+	}
+	if gaps := NewIntervalSet().Gaps(Interval{0, 50}); fmt.Sprint(gaps) != "[[0, 50)]" {
+		t.Errorf("empty set Gaps = %v", gaps)
+	}
+}
@@ -0,0 +1,124 @@
+package datetime
+
+import (
+	"iter"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//迭代范围结束时间的处理方式
+type RangeEnd int
+
+const (
+	RangeExclusive RangeEnd = iota //不含结束时间 [from, to), 开始时间等于to的单位不迭代
+	RangeInclusive                 //含结束时间 [from, to], 开始时间等于to的单位也迭代
+)
+
+//从start开始, 按next依次迭代到to, next出错或不再前进时(超出时间戳范围)结束
+func unixRange(start, to int64, end RangeEnd, next func(int64) (int64, error)) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		for unix := start; unix < to || (end == RangeInclusive && unix == to); {
+			if !yield(unix) {
+				return
+			}
+			following, err := next(unix)
+			if err != nil || following <= unix {
+				return
+			}
+			unix = following
+		}
+	}
+}
+
+//@description: 按时间单位迭代from到to之间每个单位开始的秒级时间戳
+//              第一个为from所在单位的开始, 之后按当地日历计算下一个单位的开始(而不是加固定秒数), 总是对齐到当地0时或1日
+//              下一个单位超出时间戳范围时迭代结束
+//              时区的偏移(zone.Offset())是固定的, 不处理夏令时: 每天都按24小时计算, 在夏令时地区切换日前后的0时会相差1小时
+//              如: for unix := range seq { ... }
+//@param:       from, to int64 "开始,结束的秒级时间戳"
+//@param:       unit Unit "时间单位" 日,周(星期1为开始),月,季度,半年,年等
+//@param:       zone TimeZone "时区"
+//@param:       end RangeEnd "结束时间的处理方式"
+//@return:      iter.Seq[int64] "秒级时间戳的迭代器"
+//@return:      error "错误信息"
+func UnixRange(from, to int64, unit Unit, zone TimeZone, end RangeEnd) (iter.Seq[int64], error) {
+	start, err := UnixStartOf(from, unit, zone)
+	if err != nil {
+		return nil, err
+	}
+	next := func(unix int64) (int64, error) {
+		last, err := UnixEndOf(unix, unit, zone)
+		return last + 1, err
+	}
+	return unixRange(start, to, end, next), nil
+}
+
+//@description: 按周迭代from到to之间每周开始(0时)的秒级时间戳, 可指定一周的第一天
+//@param:       from, to int64 "开始,结束的秒级时间戳"
+//@param:       firstDay int "一周的第一天(1-7)" 如: 1星期1为一周的开始, 7星期天为一周的开始
+//@param:       zone TimeZone "时区"
+//@param:       end RangeEnd "结束时间的处理方式"
+//@return:      iter.Seq[int64] "秒级时间戳的迭代器"
+//@return:      error "错误信息"
+func UnixWeekRange(from, to int64, firstDay int, zone TimeZone, end RangeEnd) (iter.Seq[int64], error) {
+	start, err := UnixStartOfWeek(from, firstDay, zone)
+	if err != nil {
+		return nil, err
+	}
+	next := func(unix int64) (int64, error) {
+		return UnixDayZeroHourNext(unix, 7, 0, 0, 0, zone)
+	}
+	return unixRange(start, to, end, next), nil
+}
+
+//秒级时间戳的迭代器 -> DateTime 的迭代器
+func dateTimeSeq(seq iter.Seq[int64], zone TimeZone) iter.Seq[*DateTime] {
+	return func(yield func(*DateTime) bool) {
+		for unix := range seq {
+			if !yield(UnixToDateTime(unix, zone)) {
+				return
+			}
+		}
+	}
+}
+
+//@description: 按时间单位迭代from到to之间每个单位的开始, 使用from的时区, 每次返回新的 DateTime
+//@param:       from, to *DateTime "开始,结束"
+//@param:       unit Unit "时间单位"
+//@param:       end RangeEnd "结束时间的处理方式"
+//@return:      iter.Seq[*DateTime] "DateTime 的迭代器"
+//@return:      error "错误信息"
+func DateTimeRange(from, to *DateTime, unit Unit, end RangeEnd) (iter.Seq[*DateTime], error) {
+	seq, err := UnixRange(from.unix, to.unix, unit, from.zone, end)
+	if err != nil {
+		return nil, err
+	}
+	return dateTimeSeq(seq, from.zone), nil
+}
+
+//@description: 按周迭代from到to之间每周的开始, 使用from的时区, 每次返回新的 DateTime
+//@param:       from, to *DateTime "开始,结束"
+//@param:       firstDay int "一周的第一天(1-7)"
+//@param:       end RangeEnd "结束时间的处理方式"
+//@return:      iter.Seq[*DateTime] "DateTime 的迭代器"
+//@return:      error "错误信息"
+func DateTimeWeekRange(from, to *DateTime, firstDay int, end RangeEnd) (iter.Seq[*DateTime], error) {
+	seq, err := UnixWeekRange(from.unix, to.unix, firstDay, from.zone, end)
+	if err != nil {
+		return nil, err
+	}
+	return dateTimeSeq(seq, from.zone), nil
+}
+
+//@description: 迭代from到to之间的每一天(含to), 不需要时区
+//@param:       from, to Date "开始,结束日期"
+//@return:      iter.Seq[Date] "日期的迭代器"
+func DateRange(from, to Date) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for days := from.DayNumber(); days <= to.DayNumber(); days++ {
+			if !yield(DayNumberToDate(days)) {
+				return
+			}
+		}
+	}
+}
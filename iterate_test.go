@@ -0,0 +1,89 @@
+package datetime
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestUnixRange(t *testing.T) {
+	from, _, _, _ := DateClockToUnix(2020, 1, 31, 12, 0, 0, Zones.E8)
+	to, _, _, _ := DateClockToUnix(2020, 5, 1, 0, 0, 0, Zones.E8)
+	for _, c := range []struct {
+		end  RangeEnd
+		want []int
+	}{{RangeExclusive, []int{1, 2, 3, 4}}, {RangeInclusive, []int{1, 2, 3, 4, 5}}} {
+		seq, err := UnixRange(from, to, UnitMonth, Zones.E8, c.end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var months []int
+		for unix := range seq {
+			year, month, day, hour, _, _, _, _ := UnixToDateClock(unix, Zones.E8)
+			if year != 2020 || day != 1 || hour != 0 {
+				t.Fatalf("UnixRange yielded %v", UnixToYmdHMS(unix, Zones.E8))
+			}
+			months = append(months, month)
+		}
+		if len(months) != len(c.want) || months[0] != c.want[0] || months[len(months)-1] != c.want[len(c.want)-1] {
+			t.Errorf("UnixRange(%v) months = %v, want %v", c.end, months, c.want)
+		}
+	}
+}
+
+//下一个单位超出时间戳范围时结束, 而不是回绕或死循环
+func TestUnixRangeLimit(t *testing.T) {
+	from := int64(maxDayNumber-800) * daySec
+	for _, unit := range []Unit{UnitDay, UnitWeek, UnitMonth, UnitYear} {
+		seq, err := UnixRange(from, math.MaxInt64, unit, Zones.E8, RangeInclusive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		count, last := 0, int64(math.MinInt64)
+		for unix := range seq {
+			if unix <= last || count > 1000 {
+				t.Fatalf("UnixRange(%v) yielded %v after %v", unit, unix, last)
+			}
+			last = unix
+			count++
+		}
+		if count == 0 {
+			t.Errorf("UnixRange(%v) yielded nothing", unit)
+		}
+	}
+	seq, err := UnixWeekRange(from, math.MaxInt64, 1, Zones.E8, RangeInclusive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for range seq {
+		if count++; count > 200 {
+			t.Fatal("UnixWeekRange did not stop")
+		}
+	}
+}
+
+//时区偏移固定, 不处理夏令时: 每天都是24小时, 总是对齐到当地0时
+func TestUnixRangeFixedOffset(t *testing.T) {
+	//2021-03-14 是美国夏令时开始的日期, 固定偏移的时区不受影响
+	from, _, _, _ := DateClockToUnix(2021, 3, 12, 15, 0, 0, Zones.E8)
+	to, _, _, _ := DateClockToUnix(2021, 3, 17, 0, 0, 0, Zones.E8)
+	seq, err := UnixRange(from, to, UnitDay, Zones.E8, RangeExclusive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var days []int64
+	for unix := range seq {
+		if (unix+Zones.E8.Offset())%daySec != 0 {
+			t.Fatalf("UnixRange yielded %v", UnixToYmdHMS(unix, Zones.E8))
+		}
+		if n := len(days); n > 0 && unix-days[n-1] != daySec {
+			t.Errorf("UnixRange step = %d, want %d", unix-days[n-1], daySec)
+		}
+		days = append(days, unix)
+	}
+	if len(days) != 5 {
+		t.Errorf("UnixRange yielded %d days, want 5", len(days))
+	}
+}
@@ -0,0 +1,199 @@
+package datetime
+
+import (
+	"math"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+const jdnUnixEpoch = 2440588 //1970年1月1日的儒略日数(JDN)
+const mjdOffset = 2400000.5  //简化儒略日(MJD) = 儒略日(JD) - 2400000.5
+const mjdUnixEpoch = 40587   //1970年1月1日0时的简化儒略日
+
+//@description: 秒级时间戳 -> 儒略日(JD), 带小数, 整数部分从正午开始
+//@param:       unix int64 "秒级时间戳"
+//@return:      float64 "儒略日"
+func UnixToJulianDay(unix int64) float64 {
+	return float64(unix)/daySec + jdUnixEpoch
+}
+
+//@description: 儒略日(JD) -> 秒级时间戳, 四舍五入到秒
+//@param:       jd float64 "儒略日"
+//@return:      int64 "秒级时间戳"
+func JulianDayToUnix(jd float64) int64 {
+	return int64(math.Floor((jd-jdUnixEpoch)*daySec + 0.5))
+}
+
+//@description: 秒级时间戳 -> 简化儒略日(MJD), 带小数, 整数部分从0时开始
+//@param:       unix int64 "秒级时间戳"
+//@return:      float64 "简化儒略日"
+func UnixToModifiedJulianDay(unix int64) float64 {
+	return float64(unix)/daySec + mjdUnixEpoch
+}
+
+//@description: 简化儒略日(MJD) -> 秒级时间戳, 四舍五入到秒
+//@param:       mjd float64 "简化儒略日"
+//@return:      int64 "秒级时间戳"
+func ModifiedJulianDayToUnix(mjd float64) int64 {
+	return int64(math.Floor((mjd-mjdUnixEpoch)*daySec + 0.5))
+}
+
+//@description: 儒略日(JD) -> 简化儒略日(MJD)
+func JulianDayToModified(jd float64) float64 {
+	return jd - mjdOffset
+}
+
+//@description: 简化儒略日(MJD) -> 儒略日(JD)
+func ModifiedToJulianDay(mjd float64) float64 {
+	return mjd + mjdOffset
+}
+
+//@description: 返回时间戳所在当地日期的儒略日数(JDN), 即该日正午的儒略日
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "儒略日数"
+func UnixJulianDayNumber(unix int64, zone TimeZone) int64 {
+	return localDayNumber(unix, zone) + jdnUnixEpoch
+}
+
+//@description: 返回儒略日数
+//@return:      int64 "儒略日数"
+func (my *DateTime) JulianDayNumber() int64 {
+	return UnixJulianDayNumber(my.unix, my.zone)
+}
+
+//@description: 返回儒略日(JD)
+//@return:      float64 "儒略日"
+func (my *DateTime) JulianDay() float64 {
+	return UnixToJulianDay(my.unix)
+}
+
+//儒略历(前推), 使用天文纪年: 公元前1年为0年
+type julianCalendar struct{}
+
+var JulianCalendar Calendar = julianCalendar{}
+
+func julianLeapYear(year int) bool {
+	return floorMod(int64(year), 4) == 0
+}
+
+//儒略历年,月,日 -> 1970年1月1日以来的天数
+func julianDayNumber(year, month, day int) int64 {
+	a := int64(14-month) / 12
+	y := int64(year) + 4800 - a
+	m := int64(month) + 12*a - 3
+	return int64(day) + (153*m+2)/5 + 365*y + floorDiv(y, 4) - 32083 - jdnUnixEpoch
+}
+
+//1970年1月1日以来的天数 -> 儒略历年,月,日
+func julianDate(days int64) (year, month, day int) {
+	c := days + jdnUnixEpoch + 32082
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := (5*e + 2) / 153
+	day = int(e-(153*m+2)/5) + 1
+	month = int(m + 3 - 12*(m/10))
+	year = int(d - 4800 + m/10)
+	return
+}
+
+func (julianCalendar) Name() string {
+	return "julian"
+}
+
+func (my julianCalendar) ToDayNumber(year, month, day int) (int64, error) {
+	if err := checkCalendarDate(my, year, month, day); err != nil {
+		return 0, err
+	}
+	return julianDayNumber(year, month, day), nil
+}
+
+func (julianCalendar) FromDayNumber(days int64) (year, month, day int, err error) {
+	year, month, day = julianDate(days)
+	return
+}
+
+func (julianCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (julianCalendar) MonthDays(year, month int) int {
+	if month < 1 || month > 12 {
+		return 0
+	}
+	if julianLeapYear(year) {
+		return leapMonth[month-1]
+	}
+	return norMonth[month-1]
+}
+
+func (julianCalendar) MonthName(year, month int) string {
+	return GregorianCalendar.MonthName(year, month)
+}
+
+//历史历法: 改历日之前为儒略历, 之后为公历, 改历时跳过的日期无效
+type historicalCalendar struct {
+	cutover int64 //改历日(公历第一天)距1970年1月1日的天数
+}
+
+//默认改历日为1582年10月15日(公历), 前一天为1582年10月4日(儒略历)
+var HistoricalCalendar Calendar = historicalCalendar{cutover: dateDayNumber(1582, 10, 15)}
+
+//@description: 创建指定改历日的历史历法 如: 英国及其殖民地为1752年9月14日
+//@param:       year, month, day int "改历日(公历的第一天)"
+//@return:      Calendar "历法"
+//@return:      error "错误信息"
+func NewHistoricalCalendar(year, month, day int) (Calendar, error) {
+	if err := checkDateClock(year, month, day, 0, 0, 0); err != nil {
+		return nil, err
+	}
+	cutover := dateDayNumber(year, month, day)
+	if first := dateDayNumber(1582, 10, 15); cutover < first {
+		return nil, newRangeError("cutover", cutover, first, math.MaxInt64)
+	}
+	return historicalCalendar{cutover: cutover}, nil
+}
+
+func (historicalCalendar) Name() string {
+	return "historical"
+}
+
+func (my historicalCalendar) ToDayNumber(year, month, day int) (int64, error) {
+	if err := checkCalendarDate(my, year, month, day); err != nil {
+		return 0, err
+	}
+	days := dateDayNumber(year, month, day)
+	if days >= my.cutover {
+		return days, nil
+	}
+	if days := julianDayNumber(year, month, day); days < my.cutover {
+		return days, nil
+	}
+	//改历时跳过的日期, 按公历计算的天数不小于改历日时才有效
+	return 0, newRangeError("days", days, my.cutover, math.MaxInt64)
+}
+
+func (my historicalCalendar) FromDayNumber(days int64) (year, month, day int, err error) {
+	if days >= my.cutover {
+		year, month, day = dayNumberDate(days)
+	} else {
+		year, month, day = julianDate(days)
+	}
+	return
+}
+
+func (historicalCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+//返回月中最后一天的日期, 改历所在月中有被跳过的日期
+func (my historicalCalendar) MonthDays(year, month int) int {
+	if dateDayNumber(year, month, 1) >= my.cutover || julianDayNumber(year, month, 1) >= my.cutover {
+		return GregorianCalendar.MonthDays(year, month)
+	}
+	return JulianCalendar.MonthDays(year, month)
+}
+
+func (historicalCalendar) MonthName(year, month int) string {
+	return GregorianCalendar.MonthName(year, month)
+}
@@ -0,0 +1,146 @@
+package datetime
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestJulianDay(t *testing.T) {
+	cases := []struct {
+		unix int64
+		jd   float64
+		mjd  float64
+	}{
+		{0, 2440587.5, 40587},
+		{946728000, 2451545, 51544.5}, //J2000.0: 2000-01-01 12:00:00 UTC
+		{-210866760000, 0, -2400000.5},
+		{1600237815, 2459108.7710069444, 59108.271006944444}, //2020-09-16 06:30:15 UTC
+	}
+	for _, c := range cases {
+		if jd := UnixToJulianDay(c.unix); math.Abs(jd-c.jd) > 1e-9 {
+			t.Errorf("UnixToJulianDay(%v) = %v, want %v", c.unix, jd, c.jd)
+		}
+		if mjd := UnixToModifiedJulianDay(c.unix); math.Abs(mjd-c.mjd) > 1e-9 {
+			t.Errorf("UnixToModifiedJulianDay(%v) = %v, want %v", c.unix, mjd, c.mjd)
+		}
+		if unix := JulianDayToUnix(c.jd); unix != c.unix {
+			t.Errorf("JulianDayToUnix(%v) = %v, want %v", c.jd, unix, c.unix)
+		}
+		if unix := ModifiedJulianDayToUnix(c.mjd); unix != c.unix {
+			t.Errorf("ModifiedJulianDayToUnix(%v) = %v, want %v", c.mjd, unix, c.unix)
+		}
+		if mjd := JulianDayToModified(c.jd); math.Abs(mjd-c.mjd) > 1e-9 {
+			t.Errorf("JulianDayToModified(%v) = %v, want %v", c.jd, mjd, c.mjd)
+		}
+		if jd := ModifiedToJulianDay(c.mjd); math.Abs(jd-c.jd) > 1e-9 {
+			t.Errorf("ModifiedToJulianDay(%v) = %v, want %v", c.mjd, jd, c.jd)
+		}
+	}
+	//北京时间2000-01-01 07:59:59 为当地2000-01-01, 儒略日数为当天正午的儒略日
+	if jdn := UnixJulianDayNumber(946684799, Zones.E8); jdn != 2451545 {
+		t.Errorf("UnixJulianDayNumber(2000-01-01 CST) = %v, want 2451545", jdn)
+	}
+	if jdn := UnixJulianDayNumber(946655999, Zones.E8); jdn != 2451544 {
+		t.Errorf("UnixJulianDayNumber(1999-12-31 CST) = %v, want 2451544", jdn)
+	}
+}
+
+func TestJulianCalendar(t *testing.T) {
+	cases := []struct {
+		year, month, day int
+		date             string //公历
+	}{
+		{-4712, 1, 1, "-4713-11-24"},
+		{1582, 10, 5, "1582-10-15"},
+		{1582, 10, 4, "1582-10-14"},
+		{1752, 9, 3, "1752-09-14"},
+		{1900, 2, 29, "1900-03-13"},
+		{2000, 1, 1, "2000-01-14"},
+	}
+	for _, c := range cases {
+		days, err := JulianCalendar.ToDayNumber(c.year, c.month, c.day)
+		if err != nil {
+			t.Errorf("ToDayNumber(%v-%v-%v) error: %v", c.year, c.month, c.day, err)
+			continue
+		}
+		year, month, day := dayNumberDate(days)
+		if s := DateClockToFormat(year, month, day, 0, 0, 0, "%Y-%m-%d"); s != c.date {
+			t.Errorf("julian %v-%v-%v = gregorian %v, want %v", c.year, c.month, c.day, s, c.date)
+		}
+		if y, m, d, _ := JulianCalendar.FromDayNumber(days); y != c.year || m != c.month || d != c.day {
+			t.Errorf("FromDayNumber(%v) = %v-%v-%v, want %v-%v-%v", days, y, m, d, c.year, c.month, c.day)
+		}
+	}
+	//儒略日数0为儒略历公元前4713年(天文纪年-4712年)1月1日
+	if days, _ := JulianCalendar.ToDayNumber(-4712, 1, 1); days+jdnUnixEpoch != 0 {
+		t.Errorf("JDN of julian -4712-01-01 = %v, want 0", days+jdnUnixEpoch)
+	}
+	if _, err := JulianCalendar.ToDayNumber(1901, 2, 29); !errors.Is(err, ErrRange) {
+		t.Errorf("ToDayNumber(1901-02-29) error = %v, want ErrRange", err)
+	}
+	for days := dateDayNumber(-1000, 1, 1); days < dateDayNumber(3000, 1, 1); days += 7 {
+		year, month, day, _ := JulianCalendar.FromDayNumber(days)
+		if back, err := JulianCalendar.ToDayNumber(year, month, day); err != nil || back != days {
+			t.Fatalf("ToDayNumber(%v-%v-%v) = %v, %v, want %v", year, month, day, back, err, days)
+		}
+	}
+}
+
+func TestHistoricalCalendar(t *testing.T) {
+	britain, err := NewHistoricalCalendar(1752, 9, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		cal            Calendar
+		last           [3]int //改历前的最后一天(儒略历)
+		first          [3]int //改历日(公历)
+		skipFrom, skip int    //改历月中被跳过的日期
+	}{
+		{HistoricalCalendar, [3]int{1582, 10, 4}, [3]int{1582, 10, 15}, 5, 10},
+		{britain, [3]int{1752, 9, 2}, [3]int{1752, 9, 14}, 3, 11},
+	}
+	for _, c := range cases {
+		last, err := c.cal.ToDayNumber(c.last[0], c.last[1], c.last[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := c.cal.ToDayNumber(c.first[0], c.first[1], c.first[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != last+1 {
+			t.Errorf("%v: cutover %v follows %v, want next day", c.first, first, last)
+		}
+		if y, m, d, _ := c.cal.FromDayNumber(last); [3]int{y, m, d} != c.last {
+			t.Errorf("FromDayNumber(last) = %v-%v-%v, want %v", y, m, d, c.last)
+		}
+		if y, m, d, _ := c.cal.FromDayNumber(first); [3]int{y, m, d} != c.first {
+			t.Errorf("FromDayNumber(first) = %v-%v-%v, want %v", y, m, d, c.first)
+		}
+		for day := c.skipFrom; day < c.skipFrom+c.skip; day++ {
+			if _, err := c.cal.ToDayNumber(c.first[0], c.first[1], day); !errors.Is(err, ErrRange) {
+				t.Errorf("ToDayNumber(%v-%v-%v) error = %v, want ErrRange", c.first[0], c.first[1], day, err)
+			}
+		}
+		if n := c.cal.MonthDays(c.first[0], c.first[1]); n != GregorianCalendar.MonthDays(c.first[0], c.first[1]) {
+			t.Errorf("MonthDays(cutover month) = %v", n)
+		}
+	}
+	//1700年: 英国仍使用儒略历, 有2月29日
+	if days, err := britain.ToDayNumber(1700, 2, 29); err != nil || days != julianDayNumber(1700, 2, 29) {
+		t.Errorf("ToDayNumber(1700-02-29) = %v, %v", days, err)
+	}
+	if _, err := HistoricalCalendar.ToDayNumber(1700, 2, 29); !errors.Is(err, ErrRange) {
+		t.Errorf("ToDayNumber(1700-02-29) error = %v, want ErrRange", err)
+	}
+	if _, err := NewHistoricalCalendar(1582, 10, 14); !errors.Is(err, ErrRange) {
+		t.Errorf("NewHistoricalCalendar(1582-10-14) error = %v, want ErrRange", err)
+	}
+	if _, err := NewHistoricalCalendar(1752, 9, 31); !errors.Is(err, ErrRange) {
+		t.Errorf("NewHistoricalCalendar(1752-09-31) error = %v, want ErrRange", err)
+	}
+}
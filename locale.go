@@ -0,0 +1,189 @@
+package datetime
+
+import (
+	. "github.com/jingyanbin/basal"
+	"strconv"
+	"unicode/utf8"
+)
+
+//格式化和解析使用的名称
+type Locale struct {
+	Months          [12]string   //月份全称 %B
+	ShortMonths     [12]string   //月份缩写 %b
+	Weekdays        [7]string    //星期全称 %A, 星期1-7
+	ShortWeekdays   [7]string    //星期缩写 %a, 星期1-7
+	Meridiem        [2]string    //上午,下午 %p
+	ChineseNumerals bool         //%O修饰的数字指令使用中文数字 如: %OY, 解析时数字字段同时接受阿拉伯数字和中文数字
+	JustNow         string       //刚刚
+	Today           string       //今天
+	Yesterday       string       //昨天
+	Tomorrow        string       //明天
+	Ago             string       //过去的相对时间 如: "%s ago"
+	Later           string       //将来的相对时间 如: "in %s"
+	Units           [4][2]string //秒,分钟,小时,天的单数和复数 如: "%d minute", "%d minutes"
+}
+
+//英文(默认)
+var LocaleEnglish = &Locale{
+	Months:        monthNames,
+	ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Weekdays:      weekdayNames,
+	ShortWeekdays: [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+	Meridiem:      meridiemNames,
+	JustNow:       "just now",
+	Today:         "today",
+	Yesterday:     "yesterday",
+	Tomorrow:      "tomorrow",
+	Ago:           "%s ago",
+	Later:         "in %s",
+	Units:         [4][2]string{{"%d second", "%d seconds"}, {"%d minute", "%d minutes"}, {"%d hour", "%d hours"}, {"%d day", "%d days"}},
+}
+
+//中文 如: "%OY年%Om月%Od日 %A" -> 二〇二〇年九月十二日 星期六
+var LocaleChinese = &Locale{
+	Months:          [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+	ShortMonths:     [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+	Weekdays:        [7]string{"星期一", "星期二", "星期三", "星期四", "星期五", "星期六", "星期日"},
+	ShortWeekdays:   [7]string{"周一", "周二", "周三", "周四", "周五", "周六", "周日"},
+	Meridiem:        [2]string{"上午", "下午"},
+	ChineseNumerals: true,
+	JustNow:         "刚刚",
+	Today:           "今天",
+	Yesterday:       "昨天",
+	Tomorrow:        "明天",
+	Ago:             "%s前",
+	Later:           "%s后",
+	Units:           [4][2]string{{"%d秒", "%d秒"}, {"%d分钟", "%d分钟"}, {"%d小时", "%d小时"}, {"%d天", "%d天"}},
+}
+
+//中文数字: 年份逐位读 如: 二〇二〇, 其它按十进位读 如: 十二, 二十一
+func chineseNumber(n int, digitwise bool) string {
+	if n < 0 {
+		return "-" + chineseNumber(-n, digitwise)
+	}
+	if digitwise || n > 99 {
+		var s string
+		for _, c := range strconv.Itoa(n) {
+			s += chineseDigits[c-'0']
+		}
+		return s
+	}
+	switch {
+	case n <= 10:
+		return chineseDigits[n]
+	case n < 20:
+		return "十" + chineseDigits[n-10]
+	case n%10 == 0:
+		return chineseDigits[n/10] + "十"
+	}
+	return chineseDigits[n/10] + "十" + chineseDigits[n%10]
+}
+
+//中文数字字符的值, 十返回10, 非中文数字返回-1
+func chineseDigitValue(r rune) int {
+	switch r {
+	case '〇', '零':
+		return 0
+	case '一':
+		return 1
+	case '二', '两':
+		return 2
+	case '三':
+		return 3
+	case '四':
+		return 4
+	case '五':
+		return 5
+	case '六':
+		return 6
+	case '七':
+		return 7
+	case '八':
+		return 8
+	case '九':
+		return 9
+	case '十':
+		return 10
+	}
+	return -1
+}
+
+//读取中文数字, 最多w位, 返回值和结束位置
+func readChineseNumber(s string, pos, end, w int) (int, int, bool) {
+	var digits []int
+	tens := -1 //十所在的位置
+	start := pos
+	for pos < end {
+		r, size := utf8.DecodeRuneInString(s[pos:end])
+		v := chineseDigitValue(r)
+		if v < 0 {
+			break
+		}
+		if v == 10 {
+			if tens >= 0 || len(digits) > 1 {
+				break
+			}
+			tens = len(digits)
+		} else {
+			if tens < 0 && len(digits) == w {
+				break
+			}
+			if tens >= 0 && len(digits) > tens {
+				break
+			}
+			digits = append(digits, v)
+		}
+		pos += size
+	}
+	if pos == start {
+		return 0, start, false
+	}
+	if tens >= 0 {
+		high, low := 1, 0
+		if tens == 1 {
+			high = digits[0]
+		}
+		if len(digits) > tens {
+			low = digits[tens]
+		}
+		return high*10 + low, pos, true
+	}
+	n := 0
+	for _, v := range digits {
+		n = n*10 + v
+	}
+	return n, pos, true
+}
+
+//写入前面补空格的日 如: " 6"
+func appendSpaceDay(buf *[]byte, day int, locale *Locale, alt bool) {
+	if day < 10 && !(alt && locale.ChineseNumerals) {
+		*buf = append(*buf, ' ')
+	}
+	appendLocaleNumber(buf, day, 1, locale, alt)
+}
+
+//写入时区偏移 如: +0800, -0530
+func appendZoneOffset(buf *[]byte, offset int64) {
+	if offset < 0 {
+		*buf = append(*buf, '-')
+		offset = -offset
+	} else {
+		*buf = append(*buf, '+')
+	}
+	ItoAW(buf, int(offset/hourSec), 2)
+	ItoAW(buf, int(offset%hourSec/minSec), 2)
+}
+
+//写入数字, alt为true且使用中文数字时写入中文数字
+func appendLocaleNumber(buf *[]byte, n, width int, locale *Locale, alt bool) {
+	if alt && locale.ChineseNumerals {
+		*buf = append(*buf, chineseNumber(n, width == 4)...)
+		return
+	}
+	if n < 0 {
+		*buf = append(*buf, '-')
+		n = -n
+	}
+	ItoAW(buf, n, width)
+}
@@ -0,0 +1,110 @@
+package datetime
+
+import (
+	"testing"
+)
+
+func TestChineseNumber(t *testing.T) {
+	cases := []struct {
+		n         int
+		digitwise bool
+		want      string
+	}{
+		{0, false, "〇"},
+		{5, false, "五"},
+		{10, false, "十"},
+		{12, false, "十二"},
+		{20, false, "二十"},
+		{21, false, "二十一"},
+		{99, false, "九十九"},
+		{100, false, "一〇〇"},
+		{-3, false, "-三"},
+		{7, true, "七"},
+		{2020, true, "二〇二〇"},
+		{10, true, "一〇"},
+	}
+	for _, c := range cases {
+		if s := chineseNumber(c.n, c.digitwise); s != c.want {
+			t.Errorf("chineseNumber(%v, %v) = %v, want %v", c.n, c.digitwise, s, c.want)
+		}
+	}
+}
+
+func TestReadChineseNumber(t *testing.T) {
+	cases := []struct {
+		s    string
+		w    int
+		n    int
+		rest string //未读取的部分
+		ok   bool
+	}{
+		{"十二日", 2, 12, "日", true},
+		{"二十一", 2, 21, "", true},
+		{"三十", 2, 30, "", true},
+		{"十", 2, 10, "", true},
+		{"十十", 2, 10, "十", true},
+		{"三十五六", 2, 35, "六", true},
+		{"一二十", 2, 12, "十", true},
+		{"两点", 2, 2, "点", true},
+		{"零五分", 2, 5, "分", true},
+		{"〇九", 2, 9, "", true},
+		{"二〇二〇年", 4, 2020, "年", true},
+		{"二〇二〇一", 4, 2020, "一", true},
+		{"二零二四", 4, 2024, "", true},
+		{"五十九秒", 2, 59, "秒", true},
+		{"abc", 2, 0, "abc", false},
+		{"", 2, 0, "", false},
+	}
+	for _, c := range cases {
+		n, pos, ok := readChineseNumber(c.s, 0, len(c.s), c.w)
+		if n != c.n || c.s[pos:] != c.rest || ok != c.ok {
+			t.Errorf("readChineseNumber(%q, %v) = %v, %q, %v, want %v, %q, %v", c.s, c.w, n, c.s[pos:], ok, c.n, c.rest, c.ok)
+		}
+	}
+}
+
+func TestFormatLocaleChinese(t *testing.T) {
+	cases := []struct {
+		year, month, day, hour, min, sec int
+		layout                           string
+		want                             string
+	}{
+		{2020, 9, 12, 14, 5, 9, "%OY年%Om月%Od日 %A", "二〇二〇年九月十二日 星期六"},
+		{2020, 9, 12, 14, 5, 9, "%p%OI点%OM分%OS秒", "下午二点五分九秒"},
+		{2020, 9, 12, 0, 30, 0, "%p%OI点%OM分", "上午十二点三十分"},
+		{2020, 9, 13, 11, 59, 0, "%a %p %I:%M", "周日 上午 11:59"},
+		{2021, 12, 25, 20, 0, 0, "%B %b %Oe日 %OH时", "十二月 十二月 二十五日 二十时"},
+		{2021, 1, 5, 8, 0, 0, "%Oe|%e|%d|%Oy", "五| 5|05|二十一"},
+		{2021, 1, 4, 8, 0, 0, "%A %OW周 %Oj", "星期一 一周 四"},
+	}
+	for _, c := range cases {
+		if s := DateClockToFormatLocale(c.year, c.month, c.day, c.hour, c.min, c.sec, c.layout, LocaleChinese); s != c.want {
+			t.Errorf("DateClockToFormatLocale(%v, %q) = %q, want %q", c, c.layout, s, c.want)
+		}
+	}
+	//英文环境下 %O 使用阿拉伯数字
+	if s := DateClockToFormatLocale(2020, 9, 12, 14, 5, 9, "%OY-%Om-%Od %A %p", LocaleEnglish); s != "2020-09-12 Saturday PM" {
+		t.Errorf("DateClockToFormatLocale(english) = %q", s)
+	}
+}
+
+func TestParseLocaleChinese(t *testing.T) {
+	opts := ParseOptions{Locale: LocaleChinese, VariableWidth: true}
+	cases := []struct {
+		s, layout string
+		want      [6]int
+	}{
+		{"二〇二〇年九月十二日", "%Y年%m月%d日", [6]int{2020, 9, 12, 0, 0, 0}},
+		{"2020年9月十二日 下午三点", "%Y年%m月%d日 %p%I点", [6]int{2020, 9, 12, 15, 0, 0}},
+		{"二〇二〇年十二月三十一日 上午十二点零五分", "%Y年%m月%d日 %p%I点%M分", [6]int{2020, 12, 31, 0, 5, 0}},
+		{"2020-09-12 星期六 下午02:05", "%Y-%m-%d %A %p%I:%M", [6]int{2020, 9, 12, 14, 5, 0}},
+		{"2020-09-13 周日", "%Y-%m-%d %a", [6]int{2020, 9, 13, 0, 0, 0}},
+		{"二〇二〇年 十一月 五日", "%Y年 %B %d日", [6]int{2020, 11, 5, 0, 0, 0}},
+	}
+	for _, c := range cases {
+		year, month, day, hour, min, sec, err := FormatToDateClockOptions(c.s, c.layout, opts)
+		if got := [6]int{year, month, day, hour, min, sec}; err != nil || got != c.want {
+			t.Errorf("FormatToDateClockOptions(%q, %q) = %v, %v, want %v", c.s, c.layout, got, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package datetime
+
+import (
+	. "github.com/jingyanbin/timezone"
+)
+
+const lunarMinYear = 1900
+const lunarMaxYear = 2100
+
+//农历1900-2100年数据
+//低4位: 闰月月份(0为无闰月)
+//第5-16位: 1-12月大小月(由高到低, 1为30天, 0为29天)
+//第17位: 闰月大小(1为30天, 0为29天)
+var lunarInfo = [lunarMaxYear - lunarMinYear + 1]int{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, //1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, //1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, //1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, //1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, //1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, //1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, //1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, //1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, //1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, //1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, //2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, //2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, //2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, //2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, //2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, //2050-2059
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, //2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, //2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, //2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, //2090-2099
+	0x0d520, //2100
+}
+
+var heavenlyStems = [10]string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+var earthlyBranches = [12]string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+var zodiacAnimals = [12]string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+var lunarMonthNames = [12]string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+var chineseDigits = [11]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九", "十"}
+
+//农历1900年正月初一(1900年1月31日)距1970年1月1日的天数
+const lunarFirstDay = -25537
+
+//农历每年正月初一距1970年1月1日的天数, 最后一项为2101年正月初一
+var lunarYearFirstDays = initLunarYearFirstDays()
+
+func initLunarYearFirstDays() (days [lunarMaxYear - lunarMinYear + 2]int64) {
+	days[0] = lunarFirstDay
+	for i := 1; i < len(days); i++ {
+		days[i] = days[i-1] + int64(lunarYearDays(lunarMinYear+i-1))
+	}
+	return
+}
+
+//农历年闰月月份, 0为无闰月
+func lunarLeapMonth(year int) int {
+	return lunarInfo[year-lunarMinYear] & 0xf
+}
+
+//农历年闰月天数, 无闰月为0
+func lunarLeapDays(year int) int {
+	if lunarLeapMonth(year) == 0 {
+		return 0
+	}
+	if lunarInfo[year-lunarMinYear]&0x10000 != 0 {
+		return 30
+	}
+	return 29
+}
+
+//农历年某月(非闰月)天数
+func lunarMonthDays(year, month int) int {
+	if lunarInfo[year-lunarMinYear]&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+//农历年总天数
+func lunarYearDays(year int) int {
+	days := lunarLeapDays(year)
+	for m := 1; m <= 12; m++ {
+		days += lunarMonthDays(year, m)
+	}
+	return days
+}
+
+//农历日期
+type LunarDate struct {
+	Year  int  //农历年
+	Month int  //月(1-12)
+	Day   int  //日(1-30)
+	Leap  bool //是否是闰月
+}
+
+//@description: 返回农历年的闰月月份
+//@param:       year int "农历年(1900-2100)"
+//@return:      int "闰月月份" 0为无闰月或超出范围
+func LunarLeapMonth(year int) int {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return 0
+	}
+	return lunarLeapMonth(year)
+}
+
+//@description: 返回农历月的天数
+//@param:       year, month int "农历年(1900-2100),月(1-12)"
+//@param:       leap bool "是否是闰月"
+//@return:      int "天数(29或30)"
+//@return:      error "错误信息"
+func LunarMonthDays(year, month int, leap bool) (int, error) {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return 0, newRangeError("year", int64(year), lunarMinYear, lunarMaxYear)
+	}
+	if month < 1 || month > 12 {
+		return 0, newRangeError("month", int64(month), 1, 12)
+	}
+	if leap {
+		if leap := lunarLeapMonth(year); leap != month {
+			//闰月只能是该年的闰月月份, 无闰月时为0
+			return 0, newRangeError("leap", int64(month), int64(leap), int64(leap))
+		}
+		return lunarLeapDays(year), nil
+	}
+	return lunarMonthDays(year, month), nil
+}
+
+//1970年1月1日以来的天数 -> 农历日期
+func dayNumberToLunar(days int64) (ld LunarDate, err error) {
+	if first, end := lunarYearFirstDays[0], lunarYearFirstDays[len(lunarYearFirstDays)-1]; days < first || days >= end {
+		return ld, newRangeError("days", days, first, end-1)
+	}
+	i := int((days - lunarFirstDay) / 385) //农历年最多384天
+	for lunarYearFirstDays[i+1] <= days {
+		i++
+	}
+	ld.Year = lunarMinYear + i
+	offset := int(days - lunarYearFirstDays[i])
+	leap := lunarLeapMonth(ld.Year)
+	for m := 1; m <= 12; m++ {
+		n := lunarMonthDays(ld.Year, m)
+		if offset < n {
+			ld.Month, ld.Day = m, offset+1
+			return
+		}
+		offset -= n
+		if m == leap {
+			n = lunarLeapDays(ld.Year)
+			if offset < n {
+				ld.Month, ld.Day, ld.Leap = m, offset+1, true
+				return
+			}
+			offset -= n
+		}
+	}
+	return
+}
+
+//农历日期 -> 1970年1月1日以来的天数
+func (my LunarDate) dayNumber() (int64, error) {
+	days, err := LunarMonthDays(my.Year, my.Month, my.Leap)
+	if err != nil {
+		return 0, err
+	}
+	if my.Day < 1 || my.Day > days {
+		return 0, newRangeError("day", int64(my.Day), 1, int64(days))
+	}
+	res := lunarYearFirstDays[my.Year-lunarMinYear]
+	leap := lunarLeapMonth(my.Year)
+	for m := 1; m < my.Month; m++ {
+		res += int64(lunarMonthDays(my.Year, m))
+		if m == leap {
+			res += int64(lunarLeapDays(my.Year))
+		}
+	}
+	if my.Leap {
+		res += int64(lunarMonthDays(my.Year, my.Month))
+	}
+	return res + int64(my.Day-1), nil
+}
+
+//@description: 公历日期 -> 农历日期
+//@param:       year, month, day int "公历年,月,日" 范围: 1900年1月31日-2101年1月28日
+//@return:      LunarDate "农历日期"
+//@return:      error "错误信息"
+func DateToLunar(year, month, day int) (LunarDate, error) {
+	if err := checkDateClock(year, month, day, 0, 0, 0); err != nil {
+		return LunarDate{}, err
+	}
+	return dayNumberToLunar(dateDayNumber(year, month, day))
+}
+
+//@description: 农历日期 -> 公历日期
+//@param:       ld LunarDate "农历日期"
+//@return:      year, month, day int "公历年,月,日"
+//@return:      error "错误信息"
+func LunarToDate(ld LunarDate) (year, month, day int, err error) {
+	days, err := ld.dayNumber()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	year, month, day = dayNumberDate(days)
+	return
+}
+
+//@description: 秒级时间戳 -> 农历日期
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      LunarDate "农历日期"
+//@return:      error "错误信息"
+func UnixToLunar(unix int64, zone TimeZone) (LunarDate, error) {
+	return dayNumberToLunar(localDayNumber(unix, zone))
+}
+
+//@description: 农历日期 -> 当天0时的秒级时间戳
+//@param:       ld LunarDate "农历日期"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func LunarToUnix(ld LunarDate, zone TimeZone) (int64, error) {
+	days, err := ld.dayNumber()
+	if err != nil {
+		return 0, err
+	}
+	return days*daySec - zone.Offset(), nil
+}
+
+//60甲子序号(0为甲子) -> 干支
+func ganZhi(i int) string {
+	i = (i%60 + 60) % 60
+	return heavenlyStems[i%10] + earthlyBranches[i%12]
+}
+
+//@description: 返回年干支 如: 庚子
+func (my LunarDate) YearGanZhi() string {
+	return ganZhi(my.Year - 4)
+}
+
+//@description: 返回生肖 如: 鼠
+func (my LunarDate) Zodiac() string {
+	return zodiacAnimals[((my.Year-4)%12+12)%12]
+}
+
+//@description: 返回月名 如: 正月, 闰四月, 冬月, 腊月
+func (my LunarDate) MonthName() string {
+	if my.Month < 1 || my.Month > 12 {
+		return ""
+	}
+	if my.Leap {
+		return "闰" + lunarMonthNames[my.Month-1] + "月"
+	}
+	return lunarMonthNames[my.Month-1] + "月"
+}
+
+//@description: 返回日名 如: 初一, 十五, 廿五, 三十
+func (my LunarDate) DayName() string {
+	switch {
+	case my.Day < 1 || my.Day > 30:
+		return ""
+	case my.Day <= 10:
+		return "初" + chineseDigits[my.Day]
+	case my.Day < 20:
+		return "十" + chineseDigits[my.Day-10]
+	case my.Day == 20:
+		return "二十"
+	case my.Day < 30:
+		return "廿" + chineseDigits[my.Day-20]
+	}
+	return "三十"
+}
+
+//@description: 返回农历日期字符串 如: 庚子年 七月廿五
+func (my LunarDate) String() string {
+	return my.YearGanZhi() + "年 " + my.MonthName() + my.DayName()
+}
+
+//@description: 返回时间戳所在日的日干支 如: 戊午
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      string "日干支"
+func UnixDayGanZhi(unix int64, zone TimeZone) string {
+	//1970年1月1日为辛巳日(序号17)
+	return ganZhi(int(localDayNumber(unix, zone)%60) + 17)
+}
+
+//@description: 返回农历日期
+//@return:      LunarDate "农历日期"
+//@return:      error "错误信息"
+func (my *DateTime) Lunar() (LunarDate, error) {
+	return UnixToLunar(my.unix, my.zone)
+}
+
+//@description: 返回日干支 如: 戊午
+func (my *DateTime) DayGanZhi() string {
+	return UnixDayGanZhi(my.unix, my.zone)
+}
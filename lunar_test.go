@@ -0,0 +1,192 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestDateToLunar(t *testing.T) {
+	cases := []struct {
+		year, month, day int
+		want             LunarDate
+		str              string
+	}{
+		{1900, 1, 31, LunarDate{1900, 1, 1, false}, "庚子年 正月初一"},
+		{1949, 1, 29, LunarDate{1949, 1, 1, false}, "己丑年 正月初一"},
+		{1984, 2, 2, LunarDate{1984, 1, 1, false}, "甲子年 正月初一"},
+		{2000, 2, 5, LunarDate{2000, 1, 1, false}, "庚辰年 正月初一"},
+		{2000, 2, 4, LunarDate{1999, 12, 29, false}, "己卯年 腊月廿九"},
+		{2020, 1, 25, LunarDate{2020, 1, 1, false}, "庚子年 正月初一"},
+		{2020, 5, 23, LunarDate{2020, 4, 1, true}, "庚子年 闰四月初一"},
+		{2020, 9, 12, LunarDate{2020, 7, 25, false}, "庚子年 七月廿五"},
+		{2020, 10, 1, LunarDate{2020, 8, 15, false}, "庚子年 八月十五"},
+		{2017, 7, 23, LunarDate{2017, 6, 1, true}, "丁酉年 闰六月初一"},
+		{2023, 3, 22, LunarDate{2023, 2, 1, true}, "癸卯年 闰二月初一"},
+		{2024, 2, 10, LunarDate{2024, 1, 1, false}, "甲辰年 正月初一"},
+		{2025, 1, 29, LunarDate{2025, 1, 1, false}, "乙巳年 正月初一"},
+		{2025, 7, 25, LunarDate{2025, 6, 1, true}, "乙巳年 闰六月初一"},
+		{2033, 1, 31, LunarDate{2033, 1, 1, false}, "癸丑年 正月初一"},
+		{2101, 1, 28, LunarDate{2100, 12, 29, false}, "庚申年 腊月廿九"},
+	}
+	for _, c := range cases {
+		ld, err := DateToLunar(c.year, c.month, c.day)
+		if err != nil || ld != c.want || ld.String() != c.str {
+			t.Errorf("DateToLunar(%v-%v-%v) = %+v %v, %v, want %+v %v", c.year, c.month, c.day, ld, ld, err, c.want, c.str)
+			continue
+		}
+		year, month, day, err := LunarToDate(ld)
+		if err != nil || year != c.year || month != c.month || day != c.day {
+			t.Errorf("LunarToDate(%+v) = %v-%v-%v, %v", ld, year, month, day, err)
+		}
+	}
+}
+
+func TestLunarRoundTrip(t *testing.T) {
+	first := lunarYearFirstDays[0]
+	last := lunarYearFirstDays[len(lunarYearFirstDays)-1] - 1
+	prev, err := dayNumberToLunar(first)
+	if err != nil || prev != (LunarDate{lunarMinYear, 1, 1, false}) {
+		t.Fatalf("dayNumberToLunar(first) = %+v, %v", prev, err)
+	}
+	for days := first + 1; days <= last; days++ {
+		ld, err := dayNumberToLunar(days)
+		if err != nil {
+			t.Fatalf("dayNumberToLunar(%v) error: %v", days, err)
+		}
+		if back, err := ld.dayNumber(); err != nil || back != days {
+			t.Fatalf("%+v.dayNumber() = %v, %v, want %v", ld, back, err, days)
+		}
+		//相邻两天: 同月日数加1, 或下个月(闰月)初一
+		if ld.Day != 1 {
+			if ld.Year != prev.Year || ld.Month != prev.Month || ld.Leap != prev.Leap || ld.Day != prev.Day+1 {
+				t.Fatalf("day %v: %+v follows %+v", days, ld, prev)
+			}
+		} else {
+			if prev.Day < 29 {
+				t.Fatalf("day %v: month %+v has %v days", days, prev, prev.Day)
+			}
+			switch {
+			case ld.Leap:
+				if ld.Year != prev.Year || ld.Month != prev.Month || prev.Leap || LunarLeapMonth(ld.Year) != ld.Month {
+					t.Fatalf("day %v: leap %+v follows %+v", days, ld, prev)
+				}
+			case ld.Month == 1:
+				if ld.Year != prev.Year+1 || prev.Month != 12 {
+					t.Fatalf("day %v: new year %+v follows %+v", days, ld, prev)
+				}
+			default:
+				if ld.Year != prev.Year || ld.Month != prev.Month+1 {
+					t.Fatalf("day %v: %+v follows %+v", days, ld, prev)
+				}
+			}
+		}
+		prev = ld
+	}
+	if _, err := dayNumberToLunar(first - 1); !errors.Is(err, ErrRange) {
+		t.Errorf("dayNumberToLunar(first-1) error = %v, want ErrRange", err)
+	}
+	if _, err := dayNumberToLunar(last + 1); !errors.Is(err, ErrRange) {
+		t.Errorf("dayNumberToLunar(last+1) error = %v, want ErrRange", err)
+	}
+}
+
+func TestLunarLeapMonth(t *testing.T) {
+	leaps := map[int]int{1900: 8, 2001: 4, 2004: 2, 2006: 7, 2009: 5, 2012: 4, 2014: 9, 2017: 6, 2020: 4, 2023: 2, 2025: 6, 2028: 5, 2033: 11}
+	for year, month := range leaps {
+		if leap := LunarLeapMonth(year); leap != month {
+			t.Errorf("LunarLeapMonth(%v) = %v, want %v", year, leap, month)
+		}
+	}
+	for _, year := range []int{2000, 2002, 2019, 2021, 2022, 2024, lunarMinYear - 1, lunarMaxYear + 1} {
+		if leap := LunarLeapMonth(year); leap != 0 {
+			t.Errorf("LunarLeapMonth(%v) = %v, want 0", year, leap)
+		}
+	}
+	for year, month := range leaps {
+		days, err := LunarMonthDays(year, month, true)
+		if err != nil || days < 29 || days > 30 {
+			t.Errorf("LunarMonthDays(%v, %v, true) = %v, %v", year, month, days, err)
+		}
+		if _, err := LunarMonthDays(year, month%12+1, true); !errors.Is(err, ErrRange) {
+			t.Errorf("LunarMonthDays(%v, %v, true) error = %v, want ErrRange", year, month%12+1, err)
+		}
+	}
+	//农历年的天数等于各月天数之和
+	for year := lunarMinYear; year <= lunarMaxYear; year++ {
+		var sum int
+		for month := 1; month <= 12; month++ {
+			days, _ := LunarMonthDays(year, month, false)
+			sum += days
+			if month == LunarLeapMonth(year) {
+				days, _ = LunarMonthDays(year, month, true)
+				sum += days
+			}
+		}
+		if n := lunarYearFirstDays[year-lunarMinYear+1] - lunarYearFirstDays[year-lunarMinYear]; int64(sum) != n {
+			t.Fatalf("lunar year %v has %v days, months sum %v", year, n, sum)
+		}
+	}
+}
+
+func TestLunarDateError(t *testing.T) {
+	cases := []LunarDate{
+		{1899, 12, 1, false},
+		{2101, 1, 1, false},
+		{2020, 0, 1, false},
+		{2020, 13, 1, false},
+		{2020, 5, 1, true},
+		{2020, 1, 0, false},
+		{2020, 1, 31, false},
+	}
+	for _, ld := range cases {
+		if _, _, _, err := LunarToDate(ld); !errors.Is(err, ErrRange) {
+			t.Errorf("LunarToDate(%+v) error = %v, want ErrRange", ld, err)
+		}
+	}
+	if _, err := DateToLunar(1900, 1, 30); !errors.Is(err, ErrRange) {
+		t.Errorf("DateToLunar(1900-01-30) error = %v, want ErrRange", err)
+	}
+	if _, err := DateToLunar(2101, 1, 29); !errors.Is(err, ErrRange) {
+		t.Errorf("DateToLunar(2101-01-29) error = %v, want ErrRange", err)
+	}
+}
+
+func TestGanZhi(t *testing.T) {
+	years := []struct {
+		year           int
+		ganZhi, zodiac string
+	}{
+		{1900, "庚子", "鼠"},
+		{1984, "甲子", "鼠"},
+		{2000, "庚辰", "龙"},
+		{2020, "庚子", "鼠"},
+		{2024, "甲辰", "龙"},
+		{2025, "乙巳", "蛇"},
+	}
+	for _, c := range years {
+		ld := LunarDate{Year: c.year, Month: 1, Day: 1}
+		if ld.YearGanZhi() != c.ganZhi || ld.Zodiac() != c.zodiac {
+			t.Errorf("year %v = %v %v, want %v %v", c.year, ld.YearGanZhi(), ld.Zodiac(), c.ganZhi, c.zodiac)
+		}
+	}
+	days := []struct {
+		date   string
+		ganZhi string
+	}{
+		{"1949-10-01", "甲子"},
+		{"1970-01-01", "辛巳"},
+		{"2000-01-01", "戊午"},
+		{"2000-01-07", "甲子"},
+	}
+	for _, c := range days {
+		unix, err := FormatToUnix(c.date+" 23:59:59", "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := UnixDayGanZhi(unix, Zones.E8); s != c.ganZhi {
+			t.Errorf("UnixDayGanZhi(%v) = %v, want %v", c.date, s, c.ganZhi)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package datetime
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNextNumber(t *testing.T) {
+	cases := []struct {
+		s       string
+		jump, w int
+		n       int
+		ok      bool
+	}{
+		{"2020-09-12", 0, 0, 2020, true},
+		{"  12abc", 0, 0, 12, true},
+		{"20200912", 0, 4, 2020, true},
+		{"-09", 1, 0, 9, true},
+		{"--09", 1, 0, 0, false},
+		{"abc", 0, 0, 0, false},
+		{"", 0, 0, 0, false},
+		{"99999999999999999999", 0, 0, 0, false},
+	}
+	for _, c := range cases {
+		n, ok := NewNextNumber(c.s).Next(c.jump, c.w)
+		if n != c.n || ok != c.ok {
+			t.Errorf("Next(%q, %v, %v) = %v, %v, want %v, %v", c.s, c.jump, c.w, n, ok, c.n, c.ok)
+		}
+	}
+	numbers := NewNextNumber("2020/09/12 08:30:15").Numbers()
+	want := []int{2020, 9, 12, 8, 30, 15}
+	if len(numbers) != len(want) {
+		t.Fatalf("Numbers() = %v, want %v", numbers, want)
+	}
+	for i := range want {
+		if numbers[i] != want[i] {
+			t.Fatalf("Numbers() = %v, want %v", numbers, want)
+		}
+	}
+}
+
+func FuzzNextNumber(f *testing.F) {
+	f.Add("2020/09/12 08:30:15", 0, 0)
+	f.Add("20200912083015", 0, 4)
+	f.Add("a1b22c333", 1, 2)
+	f.Add("99999999999999999999", 0, 0)
+	f.Fuzz(func(t *testing.T, s string, jump, w int) {
+		next := NewNextNumber(s)
+		for {
+			pos := next.pos
+			n, ok := next.Next(jump, w)
+			if !ok {
+				if next.pos != pos {
+					t.Fatalf("Next(%q) moved from %v to %v on failure", s, pos, next.pos)
+				}
+				break
+			}
+			if next.pos <= pos || next.pos > len(s) {
+				t.Fatalf("Next(%q) moved from %v to %v", s, pos, next.pos)
+			}
+			//读到的数字是结束位置之前的连续数字
+			start := next.pos
+			for start > pos && isDigit(s[start-1]) {
+				start--
+			}
+			if w > 0 && next.pos-start > w {
+				start = next.pos - w
+			}
+			if want, err := strconv.Atoi(s[start:next.pos]); err != nil || n != want || n < 0 {
+				t.Fatalf("Next(%q) = %v, want %q", s, n, s[start:next.pos])
+			}
+		}
+	})
+}
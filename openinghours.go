@@ -0,0 +1,411 @@
+package datetime
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//OpenStreetMap opening_hours 语法(支持的子集):
+//  hours    = "24/7" | rule { ";" rule }
+//  rule     = [months] [weekdays] [times | "off" | "closed" | "open"]
+//  months   = Jan | Jan-Mar | Nov-Feb, 多个用","分隔
+//  weekdays = Mo | Mo-Fr | Sa-Mo | PH(公共假日), 多个用","分隔
+//  times    = 09:00-18:00, 多个用","分隔, 结束早于开始或超过24:00时延续到次日 如: 22:00-02:00, 18:00-26:00
+//后面的规则覆盖前面规则匹配到的日期 如: "Mo-Fr 09:00-18:00; PH off" 公共假日不营业
+//只有日期没有时间的规则为全天营业, 没有任何规则匹配的日期不营业
+
+//opening_hours 的星期缩写, 下标为星期(1-7)
+var openingWeekdays = [8]string{"", "mo", "tu", "we", "th", "fr", "sa", "su"}
+
+//一天中的营业时段, 当天0时以来的秒数 [start, end), end最大为48时
+type openingSpan struct {
+	start int64
+	end   int64
+}
+
+type openingRule struct {
+	months   [13]bool //月份, 下标为月(1-12)
+	anyMonth bool
+	weekdays [8]bool //星期, 下标为星期(1-7)
+	holiday  bool    //PH
+	anyDay   bool
+	spans    []openingSpan
+}
+
+//营业时间
+type OpeningHours struct {
+	rules    []openingRule
+	holidays *HolidaySet
+}
+
+//营业时间的搜索范围(天), 超过时认为不再变化
+const openingSearchDays = 800
+
+//解析错误中的格式名称
+const openingLayout = "opening_hours"
+
+//字段中逗号分隔的一项及其在输入中的位置
+type openingItem struct {
+	text string
+	pos  int
+}
+
+//解析错误, offset 为在 s 中的字节偏移
+func openingError(s string, offset int, err error) error {
+	return &ParseError{Input: s, Layout: openingLayout, Offset: offset, Err: err}
+}
+
+//@description: 解析 OpenStreetMap opening_hours 格式的营业时间
+//              如: Mo-Fr 09:00-18:00; Sa 10:00-14:00; PH off
+//@param:       s string "营业时间"
+//@return:      *OpeningHours "营业时间"
+//@return:      error "错误信息" 解析失败时为 *ParseError, Offset 为出错的字段或时间的位置
+func ParseOpeningHours(s string) (*OpeningHours, error) {
+	hours := &OpeningHours{}
+	for pos := 0; pos <= len(s); {
+		end := strings.IndexByte(s[pos:], ';')
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += pos
+		}
+		if strings.TrimSpace(s[pos:end]) != "" {
+			rule, err := parseOpeningRule(s, pos, end)
+			if err != nil {
+				return nil, err
+			}
+			hours.rules = append(hours.rules, rule)
+		}
+		pos = end + 1
+	}
+	if len(hours.rules) == 0 {
+		return nil, openingError(s, 0, ErrSyntax)
+	}
+	return hours, nil
+}
+
+//读取 s[pos:end] 中的下一个字段, 按逗号分为多项, 逗号后的空格不分隔字段 如: "Mo-Fr, PH", "09:00-12:00, 13:00-18:00"
+func nextOpeningField(s string, pos, end int) (items []openingItem, next int) {
+	for pos < end && isSpace(s[pos]) {
+		pos++
+	}
+	if pos == end {
+		return nil, pos
+	}
+	itemStart := pos
+	for pos < end && !isSpace(s[pos]) {
+		if s[pos] == ',' {
+			items = append(items, openingItem{text: s[itemStart:pos], pos: itemStart})
+			for pos++; pos < end && isSpace(s[pos]); pos++ {
+			}
+			itemStart = pos
+			continue
+		}
+		pos++
+	}
+	return append(items, openingItem{text: s[itemStart:pos], pos: itemStart}), pos
+}
+
+//解析 s[pos:end] 中的一条规则
+func parseOpeningRule(s string, pos, end int) (openingRule, error) {
+	rule := openingRule{anyMonth: true, anyDay: true}
+	state := false //已有时间或 off/open
+	for first := true; ; first = false {
+		items, next := nextOpeningField(s, pos, end)
+		if items == nil {
+			break
+		}
+		if state {
+			return rule, openingError(s, items[0].pos, ErrSyntax)
+		}
+		keyword := ""
+		if len(items) == 1 {
+			keyword = strings.ToLower(items[0].text)
+		}
+		switch {
+		case keyword == "24/7" || keyword == "open":
+			rule.spans = []openingSpan{{start: 0, end: daySec}}
+			state = true
+		case keyword == "off" || keyword == "closed":
+			rule.spans = []openingSpan{}
+			state = true
+		case strings.Contains(s[pos:next], ":"):
+			spans, err := parseOpeningSpans(s, items)
+			if err != nil {
+				return rule, err
+			}
+			rule.spans = spans
+			state = true
+		case first && parseOpeningMonths(items, &rule):
+		case rule.anyDay:
+			if err := parseOpeningWeekdays(s, items, &rule); err != nil {
+				return rule, err
+			}
+		default:
+			return rule, openingError(s, items[0].pos, ErrSyntax)
+		}
+		pos = next
+	}
+	if !state {
+		//只有日期时全天营业
+		rule.spans = []openingSpan{{start: 0, end: daySec}}
+	}
+	return rule, nil
+}
+
+//解析月份 如: jan, jan-mar, nov-feb, jun,aug, 不是月份时返回false
+func parseOpeningMonths(items []openingItem, rule *openingRule) bool {
+	var months [13]bool
+	for _, item := range items {
+		from, to, ok := parseOpeningRange(strings.ToLower(item.text), func(name string) int {
+			for i, month := range monthNames {
+				if strings.ToLower(month[:3]) == name {
+					return i + 1
+				}
+			}
+			return 0
+		})
+		if !ok {
+			return false
+		}
+		for m := from; ; m = m%12 + 1 {
+			months[m] = true
+			if m == to {
+				break
+			}
+		}
+	}
+	rule.months, rule.anyMonth = months, false
+	return true
+}
+
+//解析星期 如: mo, mo-fr, sa-mo, mo,we,ph
+func parseOpeningWeekdays(s string, items []openingItem, rule *openingRule) error {
+	for _, item := range items {
+		lower := strings.ToLower(item.text)
+		if lower == "ph" {
+			rule.holiday = true
+			continue
+		}
+		from, to, ok := parseOpeningRange(lower, func(name string) int {
+			for i := 1; i <= 7; i++ {
+				if openingWeekdays[i] == name {
+					return i
+				}
+			}
+			return 0
+		})
+		if !ok {
+			return openingError(s, item.pos, ErrSyntax)
+		}
+		for w := from; ; w = w%7 + 1 {
+			rule.weekdays[w] = true
+			if w == to {
+				break
+			}
+		}
+	}
+	rule.anyDay = false
+	return nil
+}
+
+//解析 a 或 a-b, index返回名称的序号, 不存在时返回0
+func parseOpeningRange(s string, index func(string) int) (from, to int, ok bool) {
+	a, b, found := strings.Cut(s, "-")
+	if from = index(a); from == 0 {
+		return 0, 0, false
+	}
+	to = from
+	if found {
+		if to = index(b); to == 0 {
+			return 0, 0, false
+		}
+	}
+	return from, to, true
+}
+
+//解析时间段 如: 09:00-12:00,13:00-18:00
+func parseOpeningSpans(s string, items []openingItem) ([]openingSpan, error) {
+	var spans []openingSpan
+	for _, item := range items {
+		a, b, found := strings.Cut(item.text, "-")
+		if !found {
+			return nil, openingError(s, item.pos+len(item.text), ErrSyntax)
+		}
+		start, err := parseOpeningTime(a, 24)
+		if err == nil && start >= daySec {
+			err = newRangeError("hour", start/hourSec, 0, 23)
+		}
+		if err != nil {
+			return nil, openingError(s, item.pos, err)
+		}
+		end, err := parseOpeningTime(b, 48)
+		if err != nil {
+			return nil, openingError(s, item.pos+len(a)+1, err)
+		}
+		if end <= start {
+			end += daySec
+		}
+		if end-start > daySec {
+			return nil, openingError(s, item.pos, newRangeError("duration", end-start, 1, daySec))
+		}
+		spans = append(spans, openingSpan{start: start, end: end})
+	}
+	return spans, nil
+}
+
+//解析 hh:mm 为当天的秒数, 小时最大为maxHour(此时分钟必须为0)
+//格式错误时为 ErrSyntax, 超出范围时为 *RangeError
+func parseOpeningTime(s string, maxHour int) (int64, error) {
+	h, m, found := strings.Cut(s, ":")
+	if !found || len(h) == 0 || len(h) > 2 || len(m) != 2 || !allDigits(h) || !allDigits(m) {
+		return 0, ErrSyntax
+	}
+	hour, _ := strconv.Atoi(h)
+	min, _ := strconv.Atoi(m)
+	if hour > maxHour {
+		return 0, newRangeError("hour", int64(hour), 0, int64(maxHour))
+	}
+	if min > 59 {
+		return 0, newRangeError("min", int64(min), 0, 59)
+	}
+	if hour == maxHour && min > 0 {
+		return 0, newRangeError("min", int64(min), 0, 0)
+	}
+	return int64(hour*hourSec + min*minSec), nil
+}
+
+//@description: 设置公共假日(PH), 放假类型的节假日视为公共假日
+//@param:       set *HolidaySet "节假日集合" nil时没有公共假日
+func (my *OpeningHours) SetHolidays(set *HolidaySet) {
+	my.holidays = set
+}
+
+//1970年1月1日以来的第N天是否是公共假日
+func (my *OpeningHours) isHoliday(days int64) bool {
+	if my.holidays == nil {
+		return false
+	}
+	h, ok := my.holidays.Get(days)
+	return ok && h.Kind == HolidayOff
+}
+
+//1970年1月1日以来的第N天的营业时段, 由最后一条匹配的规则决定
+func (my *OpeningHours) daySpans(days int64) []openingSpan {
+	_, month, _ := dayNumberDate(days)
+	week := dayNumberWeekdayA(days)
+	var spans []openingSpan
+	holiday := -1
+	for i := range my.rules {
+		rule := &my.rules[i]
+		if !rule.anyMonth && !rule.months[month] {
+			continue
+		}
+		if !rule.anyDay && !rule.weekdays[week] {
+			if !rule.holiday {
+				continue
+			}
+			if holiday < 0 {
+				holiday = 0
+				if my.isHoliday(days) {
+					holiday = 1
+				}
+			}
+			if holiday == 0 {
+				continue
+			}
+		}
+		spans = rule.spans
+	}
+	return spans
+}
+
+//把第first到last天的营业时段加入集合, 以当地时间的秒数表示
+func (my *OpeningHours) addSpans(set *IntervalSet, first, last int64) {
+	for days := first; days <= last; days++ {
+		for _, span := range my.daySpans(days) {
+			set.Add(Interval{Start: days*daySec + span.start, End: days*daySec + span.end})
+		}
+	}
+}
+
+//@description: 是否营业
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      bool "是否营业"
+func (my *OpeningHours) IsOpen(unix int64, zone TimeZone) bool {
+	local := unix + zone.Offset()
+	days := floorDiv(local, daySec)
+	set := NewIntervalSet()
+	//前一天的营业时段可能延续到当天
+	my.addSpans(set, days-1, days)
+	return set.Contains(local)
+}
+
+//@description: 下一次营业状态变化(开门或关门)的时间
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "变化时的秒级时间戳" 营业中时为关门时间, 否则为开门时间
+//@return:      bool "是否会变化" 如: 24/7 或一直不营业时为false
+func (my *OpeningHours) NextChange(unix int64, zone TimeZone) (int64, bool) {
+	local := unix + zone.Offset()
+	days := floorDiv(local, daySec)
+	set := NewIntervalSet()
+	my.addSpans(set, days-1, days-1)
+	for last := days; last < days+openingSearchDays; last += 7 {
+		my.addSpans(set, last, last+6)
+		//之后的天的营业时段不会早于它们的0时, 此前的状态已确定
+		covered := (last + 7) * daySec
+		for _, iv := range set.intervals {
+			if iv.End <= local {
+				continue
+			}
+			change := iv.End
+			if iv.Start > local {
+				change = iv.Start
+			}
+			if change < covered {
+				return change - zone.Offset(), true
+			}
+			break
+		}
+	}
+	return 0, false
+}
+
+//@description: from到to之间的营业时段
+//@param:       from, to int64 "开始,结束的秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      []Interval "营业时段(秒级时间戳)"
+func (my *OpeningHours) OpenIntervals(from, to int64, zone TimeZone) []Interval {
+	if to <= from {
+		return nil
+	}
+	offset := zone.Offset()
+	set := NewIntervalSet()
+	my.addSpans(set, floorDiv(from+offset, daySec)-1, floorDiv(to+offset, daySec))
+	set = set.Intersect(NewIntervalSet(Interval{Start: from + offset, End: to + offset}))
+	intervals := set.intervals
+	for i := range intervals {
+		intervals[i].Start -= offset
+		intervals[i].End -= offset
+	}
+	return intervals
+}
+
+//@description: 是否营业
+//@param:       hours *OpeningHours "营业时间"
+//@return:      bool "是否营业"
+func (my *DateTime) IsOpen(hours *OpeningHours) bool {
+	return hours.IsOpen(my.unix, my.zone)
+}
+
+//@description: 下一次营业状态变化的时间
+//@param:       hours *OpeningHours "营业时间"
+//@return:      int64 "秒级时间戳"
+//@return:      bool "是否会变化"
+func (my *DateTime) NextOpeningChange(hours *OpeningHours) (int64, bool) {
+	return hours.NextChange(my.unix, my.zone)
+}
@@ -0,0 +1,216 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func openingUnix(t *testing.T, s string) int64 {
+	unix, err := FormatToUnix(s, "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return unix
+}
+
+func TestParseOpeningHoursError(t *testing.T) {
+	cases := []struct {
+		s      string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrSyntax},
+		{" ; ;", 0, ErrSyntax},
+		{"Xx 09:00-10:00", 0, ErrSyntax},
+		{"Mo,Xx 09:00-10:00", 3, ErrSyntax},
+		{"Mo, Xx 09:00-10:00", 4, ErrSyntax},
+		{"Mo-Fr 09:00-18:00 off", 18, ErrSyntax},
+		{"Jan Mo Tu", 7, ErrSyntax},
+		{"Mo-Fr 09:00-12:00; Sa Xx", 22, ErrSyntax},
+		{"Mo-Fr 09:00", 11, ErrSyntax},
+		{"Mo 09:00-18:00,", 15, ErrSyntax},
+		{"Mo 9:0-10:00", 3, ErrSyntax},
+		{"Mo 09:00-1a:00", 9, ErrSyntax},
+		{"Mo 09:00-25:61", 9, ErrRange},
+		{"Mo 24:00-25:00", 3, ErrRange},
+		{"Mo 09:00-48:30", 9, ErrRange},
+		{"Mo 09:00-49:00", 9, ErrRange},
+		{"Mo 01:00-30:00", 3, ErrRange},
+	}
+	for _, c := range cases {
+		_, err := ParseOpeningHours(c.s)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseOpeningHours(%q) error = %v, want *ParseError", c.s, err)
+			continue
+		}
+		if pe.Input != c.s || pe.Offset != c.offset || !errors.Is(err, c.err) {
+			t.Errorf("ParseOpeningHours(%q) error = %v offset %d, want %v offset %d", c.s, err, pe.Offset, c.err, c.offset)
+		}
+	}
+}
+
+func TestOpeningHoursIsOpen(t *testing.T) {
+	holidays := NewHolidaySet()
+	holidays.Add(Holiday{Days: dateDayNumber(2020, 10, 1), Name: "国庆节", Kind: HolidayOff})
+	holidays.Add(Holiday{Days: dateDayNumber(2020, 9, 27), Name: "国庆节", Kind: HolidayWorkday})
+	cases := []struct {
+		hours string
+		times map[string]bool
+	}{
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", map[string]bool{
+			"2020-09-16 08:59:59": false,
+			"2020-09-16 09:00:00": true,
+			"2020-09-16 11:59:59": true,
+			"2020-09-16 12:00:00": false,
+			"2020-09-16 13:00:00": true,
+			"2020-09-16 18:00:00": false,
+			"2020-09-19 21:59:59": false,
+			"2020-09-19 22:00:00": true,
+			"2020-09-20 01:59:59": true, //周六延续到周日凌晨
+			"2020-09-20 02:00:00": false,
+			"2020-09-27 10:00:00": false, //调休上班不是公共假日
+			"2020-10-01 10:00:00": false, //公共假日
+			"2020-10-02 10:00:00": true,
+		}},
+		{"Nov-Feb Mo-Fr 10:00-16:00; Mar-Oct Mo-Fr 09:00-18:00", map[string]bool{
+			"2020-09-16 09:30:00": true,
+			"2020-12-01 09:30:00": false,
+			"2020-12-01 10:30:00": true,
+			"2021-02-26 15:59:59": true,
+			"2021-03-01 09:30:00": true,
+		}},
+		{"24/7", map[string]bool{"2020-09-16 00:00:00": true, "2020-10-01 23:59:59": true}},
+		{"Mo-Su; We off", map[string]bool{"2020-09-15 12:00:00": true, "2020-09-16 12:00:00": false}},
+		{"Su 20:00-44:00", map[string]bool{"2020-09-20 19:59:59": false, "2020-09-21 19:59:59": true, "2020-09-21 20:00:00": false}},
+	}
+	for _, c := range cases {
+		hours, err := ParseOpeningHours(c.hours)
+		if err != nil {
+			t.Fatalf("ParseOpeningHours(%q) error: %v", c.hours, err)
+		}
+		hours.SetHolidays(holidays)
+		for s, want := range c.times {
+			if got := hours.IsOpen(openingUnix(t, s), Zones.E8); got != want {
+				t.Errorf("%q IsOpen(%s) = %v, want %v", c.hours, s, got, want)
+			}
+		}
+	}
+	//没有设置节假日时 PH 规则不匹配
+	hours, _ := ParseOpeningHours("Mo-Fr 09:00-18:00; PH off")
+	if !hours.IsOpen(openingUnix(t, "2020-10-01 10:00:00"), Zones.E8) {
+		t.Errorf("IsOpen without holidays = false")
+	}
+	if !UnixToDateTime(openingUnix(t, "2020-10-01 10:00:00"), Zones.E8).IsOpen(hours) {
+		t.Errorf("DateTime.IsOpen = false")
+	}
+}
+
+func TestOpeningHoursNextChange(t *testing.T) {
+	holidays := NewHolidaySet()
+	holidays.Add(Holiday{Days: dateDayNumber(2020, 10, 1), Name: "国庆节", Kind: HolidayOff})
+	cases := []struct {
+		hours string
+		from  string
+		want  string //空字符串表示不会变化
+	}{
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-16 08:00:00", "2020-09-16 09:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-16 10:00:00", "2020-09-16 12:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-16 12:00:00", "2020-09-16 13:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-18 18:00:00", "2020-09-19 22:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-19 23:00:00", "2020-09-20 02:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-20 01:00:00", "2020-09-20 02:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-20 03:00:00", "2020-09-21 09:00:00"},
+		{"Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00; PH off", "2020-09-30 18:30:00", "2020-10-02 09:00:00"},
+		//跨越0时的时段与次日的时段相接时合并
+		{"Mo 18:00-26:00; Tu 01:00-03:00", "2020-09-21 20:00:00", "2020-09-22 03:00:00"},
+		{"Mo-Su 00:00-24:00", "2020-09-16 10:00:00", ""},
+		{"Mo-Su 12:00-12:00", "2020-09-16 10:00:00", ""},
+		{"24/7", "2020-09-16 10:00:00", ""},
+		{"off", "2020-09-16 10:00:00", ""},
+		{"Dec 10:00-11:00", "2020-09-16 10:00:00", "2020-12-01 10:00:00"},
+	}
+	for _, c := range cases {
+		hours, err := ParseOpeningHours(c.hours)
+		if err != nil {
+			t.Fatalf("ParseOpeningHours(%q) error: %v", c.hours, err)
+		}
+		hours.SetHolidays(holidays)
+		change, ok := hours.NextChange(openingUnix(t, c.from), Zones.E8)
+		got := ""
+		if ok {
+			got = cstString(change)
+		}
+		if got != c.want {
+			t.Errorf("%q NextChange(%s) = %q, want %q", c.hours, c.from, got, c.want)
+		}
+	}
+}
+
+//只在公共假日营业, 搜索范围为800天
+func TestOpeningHoursNextChangeLimit(t *testing.T) {
+	hours, err := ParseOpeningHours("PH 10:00-12:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := int64(relativeRef)
+	days := localDayNumber(from, Zones.E8)
+	for _, c := range []struct {
+		after int64
+		ok    bool
+	}{{1, true}, {700, true}, {790, true}, {900, false}} {
+		holidays := NewHolidaySet()
+		holidays.Add(Holiday{Days: days + c.after, Kind: HolidayOff})
+		hours.SetHolidays(holidays)
+		change, ok := hours.NextChange(from, Zones.E8)
+		if ok != c.ok || (ok && change != (days+c.after)*daySec+10*hourSec-Zones.E8.Offset()) {
+			t.Errorf("holiday after %d days: NextChange = %s, %v", c.after, cstString(change), ok)
+		}
+	}
+	dt := UnixToDateTime(from, Zones.E8)
+	if _, ok := dt.NextOpeningChange(hours); ok {
+		t.Errorf("DateTime.NextOpeningChange found a change beyond the search range")
+	}
+}
+
+func TestOpeningHoursOpenIntervals(t *testing.T) {
+	hours, err := ParseOpeningHours("Mo-Fr 09:00-12:00, 13:00-18:00; Sa 22:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		from, to string
+		want     []string
+	}{
+		{"2020-09-19 00:00:00", "2020-09-22 00:00:00", []string{
+			"2020-09-19 22:00:00", "2020-09-20 02:00:00",
+			"2020-09-21 09:00:00", "2020-09-21 12:00:00",
+			"2020-09-21 13:00:00", "2020-09-21 18:00:00",
+		}},
+		//前一天延续过来的时段和结束处的时段被截断
+		{"2020-09-20 01:00:00", "2020-09-21 10:00:00", []string{
+			"2020-09-20 01:00:00", "2020-09-20 02:00:00",
+			"2020-09-21 09:00:00", "2020-09-21 10:00:00",
+		}},
+		{"2020-09-16 12:00:00", "2020-09-16 13:00:00", nil},
+		{"2020-09-16 13:00:00", "2020-09-16 12:00:00", nil},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, iv := range hours.OpenIntervals(openingUnix(t, c.from), openingUnix(t, c.to), Zones.E8) {
+			got = append(got, cstString(iv.Start), cstString(iv.End))
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("OpenIntervals(%s, %s) = %v, want %v", c.from, c.to, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("OpenIntervals(%s, %s) = %v, want %v", c.from, c.to, got, c.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,543 @@
+package datetime
+
+import (
+	. "github.com/jingyanbin/timezone"
+	"unicode/utf8"
+)
+
+var monthNames = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+var weekdayNames = [7]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"} //星期1-7
+var meridiemNames = [2]string{"AM", "PM"}
+
+//时区名称及偏移秒数 RFC 2822 中已废弃的时区名称, 军用单字母时区视为0
+var zoneNames = []string{"UT", "UTC", "GMT", "Z", "EST", "EDT", "CST", "CDT", "MST", "MDT", "PST", "PDT",
+	"A", "B", "C", "D", "E", "F", "G", "H", "I", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y"}
+var zoneNameOffsets = []int64{0, 0, 0, 0, -5 * hourSec, -4 * hourSec, -6 * hourSec, -5 * hourSec, -7 * hourSec, -6 * hourSec, -8 * hourSec, -7 * hourSec,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+//时区偏移的范围 ±14:00
+const maxZoneOffset = 14 * hourSec
+
+//日期时间字符串解析选项, 零值为严格模式
+type ParseOptions struct {
+	VariableWidth  bool    //数字字段可变宽度 如: 2020/9/1 1:2:3
+	AnySeparator   bool    //忽略模板中的分隔符, 字段之间可以是任意非数字字符 如: "%Y-%m-%d" 可以识别 2020年09月01日
+	TrimSpace      bool    //忽略首尾空白
+	AllowTrailing  bool    //忽略末尾未解析的内容
+	IgnoreCase     bool    //月份,星期,上下午名称及模板中的字母不区分大小写
+	DefaultMissing bool    //模板中缺失的年使用当前年, 缺失的月,日为1 时,分,秒缺失时总是为0
+	Locale         *Locale //名称和数字的语言环境, nil为英文 使用 LocaleChinese 时数字字段同时接受中文数字
+}
+
+//严格模式: 固定宽度, 分隔符完全一致, 不允许多余内容
+var StrictParse = ParseOptions{}
+
+//宽松模式
+var LenientParse = ParseOptions{VariableWidth: true, AnySeparator: true, TrimSpace: true, IgnoreCase: true}
+
+//兼容 extend 参数的解析选项, 扩展模式与原来一样忽略末尾未解析的内容 如: 毫秒
+func extendOptions(extend bool) ParseOptions {
+	if extend {
+		return ParseOptions{VariableWidth: true, AnySeparator: true, AllowTrailing: true}
+	}
+	return StrictParse
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func lowerByte(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+type dateClockParser struct {
+	s      string
+	layout string
+	opts   ParseOptions
+	pos    int               //当前解析位置
+	end    int               //解析结束位置(不含末尾空白)
+	offset int64             //%z 解析到的时区偏移秒数
+	zoned  bool              //是否解析到时区偏移
+	starts map[string]int    //字段 -> 在字符串中的开始位置
+	fields map[string]string //字段 -> 模板指令
+}
+
+func (my *dateClockParser) error(offset int, directive string, err error) *ParseError {
+	return &ParseError{Input: my.s, Layout: my.layout, Offset: offset, Directive: directive, Err: err}
+}
+
+func (my *dateClockParser) sameByte(a, b byte) bool {
+	if my.opts.IgnoreCase {
+		return lowerByte(a) == lowerByte(b)
+	}
+	return a == b
+}
+
+func (my *dateClockParser) locale() *Locale {
+	if my.opts.Locale == nil {
+		return LocaleEnglish
+	}
+	return my.opts.Locale
+}
+
+//位置上是否是数字(包括中文数字)
+func (my *dateClockParser) digitAt(pos int) bool {
+	if isDigit(my.s[pos]) {
+		return true
+	}
+	if !my.locale().ChineseNumerals {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(my.s[pos:my.end])
+	return chineseDigitValue(r) >= 0
+}
+
+//忽略分隔符模式下, 跳过下一个字段之前的分隔符, names不为空时跳到名称开始的位置
+func (my *dateClockParser) skip(names []string) {
+	for ; my.pos < my.end; my.pos++ {
+		if names == nil {
+			if my.digitAt(my.pos) {
+				return
+			}
+		} else if my.matchName(names, my.pos) >= 0 {
+			return
+		}
+	}
+}
+
+//读取最多w位的数字, 非可变宽度时必须正好w位
+func (my *dateClockParser) number(w int, signed bool) (int, bool) {
+	return my.numberWidth(w, my.opts.VariableWidth, signed)
+}
+
+//年份最多的位数, 与 maxYear 一致
+const maxYearDigits = 12
+
+//读取%Y的年份, 可带负号, wide时可超过4位 如: -0044, 12345
+func (my *dateClockParser) year(wide bool) (int, bool) {
+	signed := !my.opts.AnySeparator
+	if !wide {
+		return my.number(4, signed)
+	}
+	pos := my.pos
+	if pos < my.end && !isDigit(my.s[pos]) && my.digitAt(pos) {
+		return my.numberWidth(maxYearDigits, true, signed)
+	}
+	if signed && pos < my.end && my.s[pos] == '-' {
+		pos++
+	}
+	digits := 0
+	for ; pos+digits < my.end && isDigit(my.s[pos+digits]); digits++ {
+	}
+	if digits <= 4 {
+		return my.number(4, signed)
+	}
+	if digits > maxYearDigits {
+		return 0, false
+	}
+	return my.numberWidth(digits, false, signed)
+}
+
+//模板中位置i之后是否紧接着数字指令 如: %Y%m%d 中的%m, 忽略分隔符时跳过分隔符
+func (my *dateClockParser) numericNext(i int) bool {
+	length := len(my.layout)
+	for i < length {
+		if my.layout[i] != '%' {
+			if !my.opts.AnySeparator {
+				return false
+			}
+			i++
+			continue
+		}
+		if i+1 == length {
+			return false
+		}
+		c := my.layout[i+1]
+		if c == 'O' && i+2 < length {
+			c = my.layout[i+2]
+		}
+		switch c {
+		case '%':
+			if !my.opts.AnySeparator {
+				return false
+			}
+			i += 2
+			continue
+		case 'Y', 'y', 'm', 'd', 'e', 'H', 'I', 'M', 'S':
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+func (my *dateClockParser) numberWidth(w int, variable, signed bool) (int, bool) {
+	pos := my.pos
+	if pos < my.end && !isDigit(my.s[pos]) && my.digitAt(pos) {
+		n, next, ok := readChineseNumber(my.s, pos, my.end, w)
+		if ok {
+			my.pos = next
+		}
+		return n, ok
+	}
+	neg := signed && pos < my.end && my.s[pos] == '-'
+	if neg {
+		pos++
+	}
+	start := pos
+	for ; pos < my.end && pos-start < w && isDigit(my.s[pos]); pos++ {
+	}
+	if pos == start || (!variable && pos-start != w) {
+		return 0, false
+	}
+	n, _ := fixedDigits(my.s[start:pos])
+	if neg {
+		n = -n
+	}
+	my.pos = pos
+	return n, true
+}
+
+//返回位置上匹配的名称序号(从0开始), 多个匹配时取最长的名称, 不匹配返回-1
+func (my *dateClockParser) matchName(names []string, pos int) int {
+	index := -1
+	for i, name := range names {
+		if my.end-pos < len(name) || (index >= 0 && len(name) <= len(names[index])) {
+			continue
+		}
+		matched := true
+		for j := 0; j < len(name); j++ {
+			if !my.sameByte(my.s[pos+j], name[j]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			index = i
+		}
+	}
+	return index
+}
+
+//读取时区偏移 如: +0800, +08:00, -0530, GMT, EST
+func (my *dateClockParser) zoneOffset() (int64, bool) {
+	pos := my.pos
+	if pos < my.end && (my.s[pos] == '+' || my.s[pos] == '-') {
+		if pos+3 > my.end {
+			return 0, false
+		}
+		hh, ok := fixedDigits(my.s[pos+1 : pos+3])
+		if !ok {
+			return 0, false
+		}
+		next := pos + 3
+		if next < my.end && my.s[next] == ':' {
+			next++
+		}
+		if next+2 > my.end {
+			return 0, false
+		}
+		mm, ok := fixedDigits(my.s[next : next+2])
+		if !ok || mm > 59 {
+			return 0, false
+		}
+		offset := int64(hh*hourSec + mm*minSec)
+		if offset > maxZoneOffset {
+			return 0, false
+		}
+		if my.s[pos] == '-' {
+			offset = -offset
+		}
+		my.pos = next + 2
+		return offset, true
+	}
+	index := my.matchName(zoneNames, pos)
+	if index < 0 {
+		return 0, false
+	}
+	my.pos += len(zoneNames[index])
+	return zoneNameOffsets[index], true
+}
+
+//读取名称, 返回名称序号(从1开始)
+func (my *dateClockParser) name(names []string) (int, bool) {
+	index := my.matchName(names, my.pos)
+	if index < 0 {
+		return 0, false
+	}
+	my.pos += len(names[index])
+	return index + 1, true
+}
+
+func (my *dateClockParser) parse(zone TimeZone) (year, month, day, hour, min, sec int, err error) {
+	my.end = len(my.s)
+	my.starts = map[string]int{}
+	my.fields = map[string]string{}
+	if my.opts.TrimSpace {
+		for ; my.pos < my.end && isSpace(my.s[my.pos]); my.pos++ {
+		}
+		for ; my.end > my.pos && isSpace(my.s[my.end-1]); my.end-- {
+		}
+	}
+	var pm int
+	var hour12 bool
+	length := len(my.layout)
+	for i := 0; i < length; {
+		c := my.layout[i]
+		if c == '%' {
+			if i+1 == length {
+				break
+			}
+			c2 := my.layout[i+1]
+			directive := my.layout[i : i+2]
+			if c2 == 'O' && i+2 < length {
+				c2 = my.layout[i+2]
+				directive = my.layout[i : i+3]
+				i += 1
+			}
+			i += 2
+			if c2 == '%' {
+				if my.opts.AnySeparator {
+					continue
+				}
+				if my.pos >= my.end || my.s[my.pos] != '%' {
+					return 0, 0, 0, 0, 0, 0, my.error(my.pos, directive, ErrSyntax)
+				}
+				my.pos++
+				continue
+			}
+			var field string
+			switch c2 {
+			case 'Y', 'y':
+				field = "year"
+			case 'm', 'b', 'B':
+				field = "month"
+			case 'd', 'e':
+				field = "day"
+			case 'H', 'I':
+				field = "hour"
+			case 'M':
+				field = "min"
+			case 'S':
+				field = "sec"
+			case 'a', 'A':
+				field = "weekday"
+			case 'p':
+				field = "meridiem"
+			case 'z':
+				field = "zone"
+			default:
+				return 0, 0, 0, 0, 0, 0, my.error(my.pos, directive, ErrFormatter)
+			}
+			locale := my.locale()
+			var names []string
+			switch c2 {
+			case 'b':
+				names = locale.ShortMonths[:]
+			case 'B':
+				names = locale.Months[:]
+			case 'a':
+				names = locale.ShortWeekdays[:]
+			case 'A':
+				names = locale.Weekdays[:]
+			case 'p':
+				names = locale.Meridiem[:]
+			}
+			if c2 == 'z' {
+				for ; my.pos < my.end && isSpace(my.s[my.pos]) && my.opts.AnySeparator; my.pos++ {
+				}
+			} else if my.opts.AnySeparator {
+				my.skip(names)
+			}
+			start := my.pos
+			var found bool
+			switch c2 {
+			case 'Y': //四位数的年份表示（0000-9999）, 公元前为负数 如: -0044, 后面不紧接数字指令时可超过4位 如: 12345
+				year, found = my.year(!my.numericNext(i))
+			case 'y': //两位数的年份表示（00-99）, 69-99为19xx, 00-68为20xx
+				if year, found = my.number(2, false); found {
+					if year >= 69 {
+						year += 1900
+					} else {
+						year += 2000
+					}
+				}
+			case 'm': //月份（01-12）
+				month, found = my.number(2, false)
+			case 'b', 'B': //月份缩写 如: Jan, 月份全称 如: January
+				month, found = my.name(names)
+			case 'd': //月内中的一天（01-31）
+				day, found = my.number(2, false)
+			case 'e': //月内中的一天, 前面可以有一个空格（ 1-31）
+				if my.pos < my.end && my.s[my.pos] == ' ' {
+					my.pos++
+				}
+				day, found = my.numberWidth(2, true, false)
+			case 'H', 'I': //24小时制小时数（00-23）, 12小时制小时数（01-12）
+				hour, found = my.number(2, false)
+				hour12 = c2 == 'I'
+			case 'M': //分钟数（00=59）
+				min, found = my.number(2, false)
+			case 'S': //秒（00-59）
+				sec, found = my.number(2, false)
+			case 'a', 'A': //星期缩写 如: Mon, 星期全称 如: Monday, 只做匹配不校验
+				_, found = my.name(names)
+			case 'p': //上午或下午 如: AM, PM
+				pm, found = my.name(names)
+			case 'z': //时区偏移 如: +0800, +08:00, GMT
+				my.offset, found = my.zoneOffset()
+				my.zoned = found
+			}
+			if !found {
+				return 0, 0, 0, 0, 0, 0, my.error(start, directive, ErrSyntax)
+			}
+			my.starts[field] = start
+			my.fields[field] = directive
+		} else {
+			i += 1
+			if my.opts.AnySeparator {
+				continue
+			}
+			if my.pos >= my.end || !my.sameByte(my.s[my.pos], c) {
+				return 0, 0, 0, 0, 0, 0, my.error(my.pos, string(c), ErrSyntax)
+			}
+			my.pos += 1
+		}
+	}
+	if my.opts.AnySeparator {
+		my.skip(nil)
+	}
+	if my.pos < my.end && !my.opts.AllowTrailing {
+		return 0, 0, 0, 0, 0, 0, my.error(my.pos, "", ErrTrailing)
+	}
+
+	if hour12 {
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, 0, 0, 0, my.error(my.starts["hour"], my.fields["hour"], newRangeError("hour", int64(hour), 1, 12))
+		}
+		if pm > 0 {
+			hour = hour%12 + (pm-1)*12
+		}
+	}
+	if err = my.missing("year", &year, zone); err != nil {
+		return 0, 0, 0, 0, 0, 0, err
+	}
+	if err = my.missing("month", &month, zone); err != nil {
+		return 0, 0, 0, 0, 0, 0, err
+	}
+	if err = my.missing("day", &day, zone); err != nil {
+		return 0, 0, 0, 0, 0, 0, err
+	}
+
+	if err = checkDateClock(year, month, day, hour, min, sec); err != nil {
+		return 0, 0, 0, 0, 0, 0, my.rangeError(err)
+	}
+	return
+}
+
+//日期时间超出范围的错误, 能找到出错的字段时指向该字段的位置和指令
+func (my *dateClockParser) rangeError(err error) *ParseError {
+	pErr := my.error(-1, "", err)
+	if rErr, ok := err.(*RangeError); ok {
+		pErr.Directive = fieldDirective(rErr.Field)
+		if directive, found := my.fields[rErr.Field]; found {
+			pErr.Directive = directive
+			pErr.Offset = my.starts[rErr.Field]
+		}
+	}
+	return pErr
+}
+
+//模板中缺失的年,月,日
+func (my *dateClockParser) missing(field string, value *int, zone TimeZone) error {
+	if _, found := my.fields[field]; found {
+		return nil
+	}
+	if !my.opts.DefaultMissing {
+		return my.error(-1, fieldDirective(field), ErrMissing)
+	}
+	if field == "year" {
+		*value, _, _, _, _, _, _, _ = UnixToDateClock(Unix(), zone)
+	} else {
+		*value = 1
+	}
+	return nil
+}
+
+//@description: 按解析选项从日期时间字符串中获取 -> 年,月,日,时,分,秒
+//@param:       s string "日期时间字符串"
+//@param:       formatter string "格式化模板" 支持: %Y %y %m %d %e %H %M %S %I %p %b %B %a %A %z %%, 数字指令可加O修饰 如: %OY
+//@param:       opts ParseOptions "解析选项" 缺失的年使用本地时区的当前年, 时区偏移(%z)只做匹配
+//@return:      year, month, day, hour, min, sec int "日期时间"
+//@return:      error "错误信息" 解析失败时为 *ParseError
+func FormatToDateClockOptions(s, formatter string, opts ParseOptions) (year, month, day, hour, min, sec int, err error) {
+	parser := dateClockParser{s: s, layout: formatter, opts: opts}
+	return parser.parse(Local())
+}
+
+//@description: 按解析选项把日期时间字符串 -> 转换为秒级时间戳
+//@param:       s string "日期时间字符串"
+//@param:       formatter string "格式化模板"
+//@param:       zone TimeZone "时区" 字符串中有时区偏移(%z)时使用字符串中的偏移, 缺失的年使用该时区的当前年
+//@param:       opts ParseOptions "解析选项"
+//@return:      unix int64 "秒级时间戳"
+//@return:      error "错误信息"
+func FormatToUnixOptions(s, formatter string, zone TimeZone, opts ParseOptions) (unix int64, err error) {
+	parser := dateClockParser{s: s, layout: formatter, opts: opts}
+	return parser.unix(zone)
+}
+
+//解析为秒级时间戳
+func (my *dateClockParser) unix(zone TimeZone) (int64, error) {
+	year, month, day, hour, min, sec, err := my.parse(zone)
+	if err != nil {
+		return 0, err
+	}
+	offset := zone.Offset()
+	if my.zoned {
+		offset = my.offset
+	}
+	unix, _, _, err := dateClockToUnix(year, month, day, hour, min, sec, offset)
+	if err != nil {
+		return 0, my.rangeError(err)
+	}
+	return unix, nil
+}
+
+//@description: 按解析选项刷新时间到 日期时间字符串
+//@param:       s string "日期时间字符串"
+//@param:       formatter string "格式化模板" 字符串中有时区偏移(%z)时按偏移换算到当前时区
+//@param:       opts ParseOptions "解析选项"
+//@return:      error "错误信息"
+func (my *DateTime) FlushToFormatOptions(s, formatter string, opts ParseOptions) error {
+	parser := dateClockParser{s: s, layout: formatter, opts: opts}
+	unix, err := parser.unix(my.zone)
+	if err != nil {
+		return err
+	}
+	my.unix = unix
+	my.flush()
+	return nil
+}
+
+//@description: 按解析选项把格式化日期时间字符串 转换为DateTime
+func FormatToDateTimeOptions(s, formatter string, zone TimeZone, opts ParseOptions) (dt *DateTime, err error) {
+	dt = &DateTime{zone: zone}
+	err = dt.FlushToFormatOptions(s, formatter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return dt, nil
+}
@@ -0,0 +1,91 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestZoneOffset(t *testing.T) {
+	cases := []struct {
+		s      string
+		offset int64
+		ok     bool
+	}{
+		{"+0800", 8 * hourSec, true},
+		{"+08:00", 8 * hourSec, true},
+		{"-0530", -(5*hourSec + 30*minSec), true},
+		{"+1400", 14 * hourSec, true},
+		{"-14:00", -14 * hourSec, true},
+		{"GMT", 0, true},
+		{"PDT", -7 * hourSec, true},
+		{"+1401", 0, false},
+		{"-1500", 0, false},
+		{"+2359", 0, false},
+		{"+9900", 0, false},
+		{"+0860", 0, false},
+		{"+08", 0, false},
+	}
+	for _, c := range cases {
+		parser := dateClockParser{s: c.s, end: len(c.s)}
+		offset, ok := parser.zoneOffset()
+		if ok != c.ok || offset != c.offset {
+			t.Errorf("zoneOffset(%q) = %v, %v, want %v, %v", c.s, offset, ok, c.offset, c.ok)
+		}
+	}
+}
+
+func FuzzFormatToDateClock(f *testing.F) {
+	f.Add("2020/09/01 12:30:45", "%Y/%m/%d %H:%M:%S")
+	f.Add("2020/9/1 1:2:3", "%Y/%m/%d %H:%M:%S")
+	f.Add("2020年09月01日", "%Y-%m-%d")
+	f.Add("Tue, 01 Sep 2020 12:30:45 +0800", "%a, %d %b %Y %H:%M:%S %z")
+	f.Add("01 September 2020 02:30 PM -14:00", "%d %B %Y %I:%M %p %z")
+	f.Add("-0044/03/15", "%Y/%m/%d")
+	f.Add("二〇二〇年九月一日", "%OY年%Om月%Od日")
+	f.Add("100%", "%d%%")
+	f.Add("2020", "%Y%")
+	f.Fuzz(func(t *testing.T, s, formatter string) {
+		for _, opts := range []ParseOptions{StrictParse, LenientParse, extendOptions(true), {Locale: LocaleChinese, VariableWidth: true}} {
+			year, month, day, hour, min, sec, err := FormatToDateClockOptions(s, formatter, opts)
+			if err != nil {
+				var pErr *ParseError
+				if !errors.As(err, &pErr) {
+					t.Fatalf("FormatToDateClockOptions(%q, %q, %+v) error %T, want *ParseError", s, formatter, opts, err)
+				}
+				continue
+			}
+			if err = checkDateClock(year, month, day, hour, min, sec); err != nil {
+				t.Fatalf("FormatToDateClockOptions(%q, %q, %+v) = %v, %v, %v, %v, %v, %v: %v", s, formatter, opts, year, month, day, hour, min, sec, err)
+			}
+		}
+	})
+}
+
+func TestParseYear(t *testing.T) {
+	cases := []struct {
+		s, formatter string
+		opts         ParseOptions
+		year         int
+		ok           bool
+	}{
+		{"2020-09-01", "%Y-%m-%d", StrictParse, 2020, true},
+		{"-0044-03-15", "%Y-%m-%d", StrictParse, -44, true},
+		{"0000-01-01", "%Y-%m-%d", StrictParse, 0, true},
+		{"12345-01-01", "%Y-%m-%d", StrictParse, 12345, true},
+		{"-292277022657/01/29", "%Y/%m/%d", StrictParse, minYear, true},
+		{"292277026596/12/03", "%Y/%m/%d", StrictParse, maxYear, true},
+		{"20200901", "%Y%m%d", StrictParse, 2020, true},
+		{"2020/9/1", "%Y/%m/%d", LenientParse, 2020, true},
+		{"20200901", "%Y-%m-%d", LenientParse, 2020, true},
+		{"12345年1月1日", "%Y年%m月%d日", ParseOptions{VariableWidth: true}, 12345, true},
+		{"044-03-15", "%Y-%m-%d", StrictParse, 0, false},
+		{"1234567890123-01-01", "%Y-%m-%d", StrictParse, 0, false},
+		{"292277026597/01/01", "%Y/%m/%d", StrictParse, 0, false},
+	}
+	for _, c := range cases {
+		year, _, _, _, _, _, err := FormatToDateClockOptions(c.s, c.formatter, c.opts)
+		if (err == nil) != c.ok || year != c.year {
+			t.Errorf("FormatToDateClockOptions(%q, %q) = %v, %v, want %v", c.s, c.formatter, year, err, c.year)
+		}
+	}
+}
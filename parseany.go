@@ -0,0 +1,356 @@
+package datetime
+
+import (
+	"strings"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//日期数字的顺序, 仅在无法判断时使用 如: 09/12/2020
+type DateOrder int
+
+const (
+	DateOrderMDY DateOrder = iota //月/日/年 美国习惯
+	DateOrderDMY                  //日/月/年 欧洲习惯
+)
+
+//中文日期时间单位替换为等长的分隔符, 保持字节偏移不变
+var anyChineseReplacer = strings.NewReplacer("年", "-  ", "月", "-  ", "日", "   ", "号", "   ", "时", ":  ", "点", ":  ", "分", ":  ", "秒", "   ")
+
+type anyToken struct {
+	num   bool   //是否是数字
+	text  string //数字或单词
+	sep   string //前面的分隔符(去掉首尾空白)
+	start int    //在字符串中的开始位置
+	used  bool   //是否已识别
+}
+
+func (my *anyToken) value() int {
+	n, _ := fixedDigits(my.text)
+	return n
+}
+
+//把字符串拆分为数字和单词, 其它字符视为分隔符
+//不使用 NextNumber: 它会跳过单词和分隔符, 而识别时需要单词(Sep, PM, GMT)和数字前的分隔符(. : + -)
+func lexAny(s string) []anyToken {
+	var tokens []anyToken
+	sepStart := 0
+	for pos := 0; pos < len(s); {
+		c := s[pos]
+		if !isDigit(c) && !isLetter(c) {
+			pos++
+			continue
+		}
+		start := pos
+		num := isDigit(c)
+		for ; pos < len(s) && ((num && isDigit(s[pos])) || (!num && isLetter(s[pos]))); pos++ {
+		}
+		tokens = append(tokens, anyToken{num: num, text: s[start:pos], sep: strings.TrimSpace(s[sepStart:start]), start: start})
+		sepStart = pos
+	}
+	return tokens
+}
+
+//名称序号(从1开始), 匹配缩写或全称, 不区分大小写
+func anyNameIndex(names []string, word string) int {
+	for i, name := range names {
+		if strings.EqualFold(word, name) || (len(word) == 3 && strings.EqualFold(word, name[:3])) {
+			return i + 1
+		}
+	}
+	if strings.EqualFold(word, "Sept") && len(names) == 12 {
+		return 9
+	}
+	return 0
+}
+
+//小数秒最多的位数(纳秒)
+const maxFractionDigits = 9
+
+//时区名称的偏移秒数 如: GMT, EST, PDT, 不区分大小写, 单字母的军用时区只接受Z
+func anyZoneOffset(word string) (int64, bool) {
+	for i, name := range zoneNames {
+		if (len(name) > 1 || name == "Z") && strings.EqualFold(word, name) {
+			return zoneNameOffsets[i], true
+		}
+	}
+	return 0, false
+}
+
+//两位数的年份: 69-99为19xx, 00-68为20xx
+func anyYear(token *anyToken) int {
+	year := token.value()
+	if len(token.text) <= 2 {
+		if year >= 69 {
+			return 1900 + year
+		}
+		return 2000 + year
+	}
+	return year
+}
+
+//纯数字: 紧凑日期时间或时间戳
+func parseAnyDigits(s string, zone TimeZone) (int64, error) {
+	n := len(s)
+	switch n {
+	case 8, 12, 14: //20200912, 202009121530, 20200912153000
+		var hour, min, sec int
+		year, _ := fixedDigits(s[0:4])
+		month, _ := fixedDigits(s[4:6])
+		day, _ := fixedDigits(s[6:8])
+		if n >= 12 {
+			hour, _ = fixedDigits(s[8:10])
+			min, _ = fixedDigits(s[10:12])
+		}
+		if n == 14 {
+			sec, _ = fixedDigits(s[12:14])
+		}
+		unix, _, _, err := DateClockToUnix(year, month, day, hour, min, sec, zone)
+		if err != nil {
+			return 0, &ParseError{Input: s, Offset: -1, Err: err}
+		}
+		return unix, nil
+	}
+	value, _ := fixedDigits(s)
+	switch {
+	case n <= 11: //秒
+		return int64(value), nil
+	case n == 13: //毫秒
+		return floorDiv(int64(value), 1e3), nil
+	case n == 16: //微秒
+		return floorDiv(int64(value), 1e6), nil
+	case n == 19: //纳秒
+		return floorDiv(int64(value), 1e9), nil
+	}
+	return 0, &ParseError{Input: s, Offset: 0, Err: ErrUnknownLayout}
+}
+
+//@description: 自动识别常见格式的日期时间字符串 -> 转换为秒级时间戳
+//              支持: 2020/09/12 00:00:00, 2020-9-12, 2020.09.12, 20200912000000, 2020-09-12T00:00:00.123+08:00,
+//              Sat, 12 Sep 2020 00:00:00 GMT(或EST, PDT等), Sat Sep 12 00:00:00 2020, 09/12/2020, 12.09.2020,
+//              2020年9月12日 0时0分, 秒/毫秒/微秒/纳秒级时间戳
+//              月/日顺序无法判断时按月/日/年, 以.分隔时按日.月.年
+//@param:       s string "日期时间字符串"
+//@param:       zone TimeZone "时区" 字符串中没有时区偏移时使用
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息" 无法识别时为 *ParseError
+func ParseAny(s string, zone TimeZone) (int64, error) {
+	return ParseAnyOrder(s, zone, DateOrderMDY)
+}
+
+//@description: 自动识别常见格式的日期时间字符串, 并指定月/日顺序无法判断时的优先顺序
+//@param:       s string "日期时间字符串"
+//@param:       zone TimeZone "时区"
+//@param:       order DateOrder "月/日顺序" 以.分隔的日期总是按日.月.年
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func ParseAnyOrder(s string, zone TimeZone, order DateOrder) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return 0, &ParseError{Input: s, Offset: 0, Err: ErrSyntax}
+	}
+	if _, ok := fixedDigits(trimmed); ok {
+		return parseAnyDigits(trimmed, zone)
+	}
+	if trimmed[0] == '-' {
+		if value, ok := fixedDigits(trimmed[1:]); ok && len(trimmed) <= 12 {
+			return -int64(value), nil
+		}
+	}
+	fail := func(offset int, err error) (int64, error) {
+		return 0, &ParseError{Input: s, Offset: offset, Err: err}
+	}
+
+	tokens := lexAny(anyChineseReplacer.Replace(s))
+	for i := range tokens {
+		if _, ok := fixedDigits(tokens[i].text); tokens[i].num && !ok {
+			return fail(tokens[i].start, ErrRange)
+		}
+	}
+	var year, month, day, hour, min, sec int
+	var hasTime, hasOffset, pm, am bool
+	var offset int64
+
+	//时间: 时:分[:秒[.小数]] 或 T之后的紧凑时间 hhmm[ss]
+	timeAt := -1
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].num && tokens[i+1].num && tokens[i+1].sep == ":" {
+			timeAt = i
+			break
+		}
+	}
+	if timeAt >= 0 {
+		hasTime = true
+		hour = tokens[timeAt].value()
+		min = tokens[timeAt+1].value()
+		tokens[timeAt].used, tokens[timeAt+1].used = true, true
+		next := timeAt + 2
+		if next < len(tokens) && tokens[next].num && tokens[next].sep == ":" {
+			sec = tokens[next].value()
+			tokens[next].used = true
+			next++
+			if next < len(tokens) && tokens[next].num && (tokens[next].sep == "." || tokens[next].sep == ",") {
+				if len(tokens[next].text) > maxFractionDigits {
+					return fail(tokens[next].start, ErrSyntax)
+				}
+				tokens[next].used = true
+				next++
+			}
+		}
+		timeAt = next
+	} else {
+		for i := 1; i < len(tokens); i++ {
+			if tokens[i].num && strings.EqualFold(tokens[i-1].text, "T") && (len(tokens[i].text) == 4 || len(tokens[i].text) == 6) {
+				text := tokens[i].text
+				hasTime = true
+				hour, _ = fixedDigits(text[0:2])
+				min, _ = fixedDigits(text[2:4])
+				if len(text) == 6 {
+					sec, _ = fixedDigits(text[4:6])
+				}
+				tokens[i].used = true
+				timeAt = i + 1
+				if timeAt < len(tokens) && tokens[timeAt].num && (tokens[timeAt].sep == "." || tokens[timeAt].sep == ",") {
+					if len(tokens[timeAt].text) > maxFractionDigits {
+						return fail(tokens[timeAt].start, ErrSyntax)
+					}
+					tokens[timeAt].used = true
+					timeAt++
+				}
+				break
+			}
+		}
+	}
+
+	//时区偏移: 时间之后的 +08:00, +0800, +08
+	if hasTime {
+		for i := timeAt; i < len(tokens); i++ {
+			token := &tokens[i]
+			if !token.num || token.used || token.sep == "" {
+				continue
+			}
+			sign := token.sep[len(token.sep)-1]
+			if sign != '+' && sign != '-' {
+				continue
+			}
+			var hh, mm int
+			switch len(token.text) {
+			case 4:
+				hh, _ = fixedDigits(token.text[0:2])
+				mm, _ = fixedDigits(token.text[2:4])
+			case 1, 2:
+				hh = token.value()
+				if i+1 < len(tokens) && tokens[i+1].num && tokens[i+1].sep == ":" && len(tokens[i+1].text) == 2 {
+					mm = tokens[i+1].value()
+					tokens[i+1].used = true
+				}
+			default:
+				return fail(token.start, ErrSyntax)
+			}
+			offset = int64(hh*hourSec + mm*minSec)
+			if mm > 59 || offset > maxZoneOffset {
+				return fail(token.start, newRangeError("offset", int64(hh*100+mm), -1400, 1400))
+			}
+			if sign == '-' {
+				offset = -offset
+			}
+			hasOffset = true
+			token.used = true
+			break
+		}
+	}
+
+	//单词: 月份, 星期, 时区 如: Z, UTC, GMT, EST, PDT, 上下午
+	for i := range tokens {
+		token := &tokens[i]
+		if token.num || token.used {
+			continue
+		}
+		word := token.text
+		if m := anyNameIndex(monthNames[:], word); m > 0 && month == 0 {
+			month = m
+		} else if anyNameIndex(weekdayNames[:], word) > 0 || strings.EqualFold(word, "T") {
+		} else if zoneOffset, ok := anyZoneOffset(word); ok {
+			//GMT+08:00 中的GMT不覆盖数字偏移
+			if !hasOffset {
+				offset = zoneOffset
+			}
+			hasOffset = true
+		} else if strings.EqualFold(word, "AM") {
+			am = true
+		} else if strings.EqualFold(word, "PM") {
+			pm = true
+		} else {
+			return fail(token.start, ErrUnknownLayout)
+		}
+		token.used = true
+	}
+
+	//剩余数字为日期
+	var nums []*anyToken
+	for i := range tokens {
+		if tokens[i].num && !tokens[i].used {
+			nums = append(nums, &tokens[i])
+		}
+	}
+	if month > 0 {
+		//Sat, 12 Sep 2020; Sep 12 2020; 12-Sep-20
+		if len(nums) != 2 {
+			return fail(-1, ErrUnknownLayout)
+		}
+		first, second := nums[0], nums[1]
+		if len(first.text) > 2 || first.value() > 31 {
+			first, second = second, first
+		}
+		day = first.value()
+		year = anyYear(second)
+	} else if len(nums) == 1 && len(nums[0].text) == 8 {
+		//20200912T000000
+		text := nums[0].text
+		year, _ = fixedDigits(text[0:4])
+		month, _ = fixedDigits(text[4:6])
+		day, _ = fixedDigits(text[6:8])
+	} else if len(nums) == 3 {
+		a, b, c := nums[0], nums[1], nums[2]
+		if len(a.text) > 2 {
+			year, month, day = a.value(), b.value(), c.value()
+		} else {
+			year = anyYear(c)
+			dmy := order == DateOrderDMY || b.sep == "."
+			if a.value() > 12 {
+				dmy = true
+			} else if b.value() > 12 {
+				dmy = false
+			}
+			if dmy {
+				day, month = a.value(), b.value()
+			} else {
+				month, day = a.value(), b.value()
+			}
+		}
+	} else {
+		at := -1
+		if len(nums) > 3 {
+			at = nums[3].start
+		}
+		return fail(at, ErrUnknownLayout)
+	}
+
+	if am || pm {
+		if hour < 1 || hour > 12 {
+			return fail(-1, newRangeError("hour", int64(hour), 1, 12))
+		}
+		hour = hour % 12
+		if pm {
+			hour += 12
+		}
+	}
+	unix, _, _, err := DateClockToUnix(year, month, day, hour, min, sec, zone)
+	if err != nil {
+		return fail(-1, err)
+	}
+	if hasOffset {
+		unix += zone.Offset() - offset
+	}
+	return unix, nil
+}
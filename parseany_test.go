@@ -0,0 +1,40 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestParseAnyZoneAndFraction(t *testing.T) {
+	cases := []struct {
+		s    string
+		unix int64
+		err  error
+	}{
+		{"Sat, 12 Sep 2020 00:00:00 GMT", 1599868800, nil},
+		{"Sat, 12 Sep 2020 00:00:00 EST", 1599886800, nil},
+		{"Sat, 12 Sep 2020 00:00:00 pdt", 1599894000, nil},
+		{"2020-09-12 00:00:00 GMT+08:00", 1599840000, nil},
+		{"2020-09-12T00:00:00.123456789+08:00", 1599840000, nil},
+		{"1599840000123456789", 1599840000, nil},
+		{"2020-09-12T00:00:00.1234567890+08:00", 0, ErrSyntax},
+		{"2020-09-12T00:00:00.1234567890123456789012+08:00", 0, ErrRange},
+		{"9999999999999999999", 0, ErrRange},
+		{"2020-09-12 00:00:00 +15:00", 0, ErrRange},
+		{"Sat, 12 Sep 2020 00:00:00 XYZ", 0, ErrUnknownLayout},
+	}
+	for _, c := range cases {
+		unix, err := ParseAny(c.s, Zones.E8)
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf("ParseAny(%q) error = %v, want %v", c.s, err, c.err)
+			}
+			continue
+		}
+		if err != nil || unix != c.unix {
+			t.Errorf("ParseAny(%q) = %v, %v, want %v", c.s, unix, err, c.unix)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package datetime
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//日历时长: 年,月按日历计算(日超出该月天数时取月末), 其它按固定长度计算
+//各字段可以为负数, 也可以不同符号 如: 1个月-1天
+type Period struct {
+	Years   int //年
+	Months  int //月
+	Days    int //天
+	Hours   int //时
+	Minutes int //分
+	Seconds int //秒
+	Nanos   int //纳秒
+}
+
+//时长单位
+const (
+	periodYears = iota
+	periodMonths
+	periodWeeks
+	periodDays
+	periodHours
+	periodMinutes
+	periodSeconds
+	periodMillis
+	periodMicros
+	periodNanos
+)
+
+//紧凑格式和中文的时长单位
+var periodUnits = map[string]int{
+	"y": periodYears, "yr": periodYears, "yrs": periodYears, "year": periodYears, "years": periodYears,
+	"mo": periodMonths, "month": periodMonths, "months": periodMonths,
+	"w": periodWeeks, "wk": periodWeeks, "week": periodWeeks, "weeks": periodWeeks,
+	"d": periodDays, "day": periodDays, "days": periodDays,
+	"h": periodHours, "hr": periodHours, "hrs": periodHours, "hour": periodHours, "hours": periodHours,
+	"m": periodMinutes, "min": periodMinutes, "mins": periodMinutes, "minute": periodMinutes, "minutes": periodMinutes,
+	"s": periodSeconds, "sec": periodSeconds, "secs": periodSeconds, "second": periodSeconds, "seconds": periodSeconds,
+	"ms": periodMillis, "us": periodMicros, "µs": periodMicros, "ns": periodNanos,
+	"年": periodYears, "个月": periodMonths, "月": periodMonths,
+	"周": periodWeeks, "星期": periodWeeks, "个星期": periodWeeks, "礼拜": periodWeeks, "个礼拜": periodWeeks,
+	"天": periodDays, "日": periodDays, "小时": periodHours, "个小时": periodHours, "时": periodHours,
+	"分钟": periodMinutes, "分": periodMinutes, "秒": periodSeconds, "秒钟": periodSeconds,
+	"毫秒": periodMillis, "微秒": periodMicros, "纳秒": periodNanos,
+}
+
+//@description: 是否为零时长
+//@return:      bool "是否为零"
+func (my Period) IsZero() bool {
+	return my == Period{}
+}
+
+//@description: 返回各字段取反的时长
+//@return:      Period "时长"
+func (my Period) Negate() Period {
+	return Period{-my.Years, -my.Months, -my.Days, -my.Hours, -my.Minutes, -my.Seconds, -my.Nanos}
+}
+
+//是否所有字段都小于等于0(且不为零时长)
+func (my Period) negative() bool {
+	return !my.IsZero() && my.Years <= 0 && my.Months <= 0 && my.Days <= 0 && my.Hours <= 0 && my.Minutes <= 0 && my.Seconds <= 0 && my.Nanos <= 0
+}
+
+//@description: 规范化: 纳秒,秒,分,时进位到天(时区偏移固定, 每天为24小时), 月进位到年, 天不进位到月
+//              进位后的天和时间各字段符号相同, 年和月符号相同
+//@return:      Period "规范化后的时长"
+func (my Period) Normalize() Period {
+	months := int64(my.Years)*12 + int64(my.Months)
+	nanos := int64(my.Nanos)
+	secs := int64(my.Days)*daySec + int64(my.Hours)*hourSec + int64(my.Minutes)*minSec + int64(my.Seconds) + floorDiv(nanos, 1e9)
+	nanos = floorMod(nanos, 1e9)
+	if secs < 0 && nanos > 0 {
+		secs++
+		nanos -= 1e9
+	}
+	return Period{
+		Years:   int(months / 12),
+		Months:  int(months % 12),
+		Days:    int(secs / daySec),
+		Hours:   int(secs % daySec / hourSec),
+		Minutes: int(secs % hourSec / minSec),
+		Seconds: int(secs % minSec),
+		Nanos:   int(nanos),
+	}
+}
+
+//写入秒和纳秒 如: 6, 6.5, -0.25
+func appendPeriodSeconds(buf []byte, sec, nanos int) []byte {
+	sec += nanos / 1e9
+	nanos %= 1e9
+	if sec > 0 && nanos < 0 {
+		sec--
+		nanos += 1e9
+	} else if sec < 0 && nanos > 0 {
+		sec++
+		nanos -= 1e9
+	}
+	if sec == 0 && nanos < 0 {
+		buf = append(buf, '-')
+	}
+	buf = strconv.AppendInt(buf, int64(sec), 10)
+	if nanos < 0 {
+		nanos = -nanos
+	}
+	if nanos != 0 {
+		digits := strconv.Itoa(nanos + 1e9)[1:]
+		buf = append(buf, '.')
+		buf = append(buf, strings.TrimRight(digits, "0")...)
+	}
+	return buf
+}
+
+//按单位写入时长, 所有字段为负数时只在开头写一个负号, 正负混合时第一个字段之后的正数写+号
+func (my Period) appendUnits(buf []byte, units [6]string, zero string) []byte {
+	if my.IsZero() {
+		return append(buf, zero...)
+	}
+	if my.negative() {
+		buf = append(buf, '-')
+		my = my.Negate()
+	}
+	sec := my.Seconds + my.Nanos/1e9
+	positiveSec := sec > 0 || (sec == 0 && my.Nanos%1e9 > 0)
+	mixed := my.Years < 0 || my.Months < 0 || my.Days < 0 || my.Hours < 0 || my.Minutes < 0 || ((my.Seconds != 0 || my.Nanos != 0) && !positiveSec)
+	first := true
+	for i, n := range [5]int{my.Years, my.Months, my.Days, my.Hours, my.Minutes} {
+		if n != 0 {
+			if n > 0 && mixed && !first {
+				buf = append(buf, '+')
+			}
+			buf = strconv.AppendInt(buf, int64(n), 10)
+			buf = append(buf, units[i]...)
+			first = false
+		}
+	}
+	if my.Seconds != 0 || my.Nanos != 0 {
+		if positiveSec && mixed && !first {
+			buf = append(buf, '+')
+		}
+		buf = appendPeriodSeconds(buf, my.Seconds, my.Nanos)
+		buf = append(buf, units[5]...)
+	}
+	return buf
+}
+
+//@description: ISO 8601 格式 如: P1Y2M3DT4H5M6.5S, 零时长为 PT0S, 所有字段为负数时为 -P1D
+//@return:      string "ISO 8601 时长字符串"
+func (my Period) String() string {
+	if my.IsZero() {
+		return "PT0S"
+	}
+	var buf []byte
+	if my.negative() {
+		buf = append(buf, '-')
+		my = my.Negate()
+	}
+	buf = append(buf, 'P')
+	for i, n := range [3]int{my.Years, my.Months, my.Days} {
+		if n != 0 {
+			buf = strconv.AppendInt(buf, int64(n), 10)
+			buf = append(buf, "YMD"[i])
+		}
+	}
+	if my.Hours != 0 || my.Minutes != 0 || my.Seconds != 0 || my.Nanos != 0 {
+		buf = append(buf, 'T')
+		for i, n := range [2]int{my.Hours, my.Minutes} {
+			if n != 0 {
+				buf = strconv.AppendInt(buf, int64(n), 10)
+				buf = append(buf, "HM"[i])
+			}
+		}
+		if my.Seconds != 0 || my.Nanos != 0 {
+			buf = appendPeriodSeconds(buf, my.Seconds, my.Nanos)
+			buf = append(buf, 'S')
+		}
+	}
+	return string(buf)
+}
+
+//@description: 紧凑格式 如: 1y2mo3d4h5m6.5s, 零时长为 0s
+//@return:      string "时长字符串"
+func (my Period) Compact() string {
+	return string(my.appendUnits(nil, [6]string{"y", "mo", "d", "h", "m", "s"}, "0s"))
+}
+
+//@description: 中文格式 如: 1年2个月3天4小时5分钟6.5秒, 零时长为 0秒
+//@return:      string "时长字符串"
+func (my Period) Chinese() string {
+	return string(my.appendUnits(nil, [6]string{"年", "个月", "天", "小时", "分钟", "秒"}, "0秒"))
+}
+
+//读取带符号的数字和小数部分(纳秒), chinese为true时接受中文数字
+func readPeriodNumber(s string, pos int, chinese bool) (n, nanos, next int, fraction, ok bool) {
+	neg := false
+	if pos < len(s) && (s[pos] == '-' || s[pos] == '+') {
+		neg = s[pos] == '-'
+		pos++
+	}
+	start := pos
+	for ; pos < len(s) && isDigit(s[pos]); pos++ {
+		if pos-start == 18 {
+			return 0, 0, pos, false, false
+		}
+		n = n*10 + int(s[pos]-'0')
+	}
+	if pos == start {
+		if !chinese {
+			return 0, 0, pos, false, false
+		}
+		value, end, found := readChineseNumber(s, pos, len(s), 18)
+		if !found {
+			return 0, 0, pos, false, false
+		}
+		n, pos = value, end
+	}
+	if pos < len(s) && (s[pos] == '.' || s[pos] == ',') {
+		pos++
+		fracStart := pos
+		scale := int(1e8)
+		for ; pos < len(s) && isDigit(s[pos]); pos++ {
+			nanos += int(s[pos]-'0') * scale
+			scale /= 10
+		}
+		if pos == fracStart {
+			return 0, 0, pos, false, false
+		}
+		fraction = true
+	}
+	if neg {
+		n, nanos = -n, -nanos
+	}
+	return n, nanos, pos, fraction, true
+}
+
+//按单位累加, 溢出时返回false
+func (my *Period) addUnit(unit, n, nanos int) bool {
+	field, factor := &my.Nanos, 1
+	switch unit {
+	case periodYears:
+		field = &my.Years
+	case periodMonths:
+		field = &my.Months
+	case periodWeeks:
+		field, factor = &my.Days, 7
+	case periodDays:
+		field = &my.Days
+	case periodHours:
+		field = &my.Hours
+	case periodMinutes:
+		field = &my.Minutes
+	case periodSeconds:
+		field = &my.Seconds
+		if !addInt(&my.Nanos, nanos) {
+			return false
+		}
+	case periodMillis:
+		factor = 1e6
+	case periodMicros:
+		factor = 1e3
+	}
+	if n > math.MaxInt/factor || n < math.MinInt/factor {
+		return false
+	}
+	return addInt(field, n*factor)
+}
+
+//累加, 溢出时返回false
+func addInt(field *int, n int) bool {
+	sum := *field + n
+	if (n > 0 && sum < *field) || (n < 0 && sum > *field) {
+		return false
+	}
+	*field = sum
+	return true
+}
+
+//ISO 8601: [n Y][n M][n W][n D][T[n H][n M][n S]], 只有秒可以有小数
+func parsePeriodISO(s string, pos int) (Period, error) {
+	var p Period
+	fail := func(offset int) (Period, error) {
+		return Period{}, &ParseError{Input: s, Layout: "ISO 8601", Offset: offset, Err: ErrSyntax}
+	}
+	designators, base := "YMWD", periodYears
+	order, count := 0, 0
+	for pos < len(s) {
+		if s[pos] == 'T' || s[pos] == 't' {
+			if base == periodHours || pos+1 == len(s) {
+				return fail(pos)
+			}
+			designators, base, order = "HMS", periodHours, 0
+			pos++
+			continue
+		}
+		start := pos
+		n, nanos, next, fraction, ok := readPeriodNumber(s, pos, false)
+		if !ok {
+			return fail(start)
+		}
+		pos = next
+		if pos == len(s) {
+			return fail(pos)
+		}
+		i := strings.IndexByte(designators, s[pos]&^0x20)
+		if i < order || (fraction && base+i != periodSeconds) {
+			return fail(pos)
+		}
+		if !p.addUnit(base+i, n, nanos) {
+			return Period{}, &ParseError{Input: s, Layout: "ISO 8601", Offset: start, Err: ErrRange}
+		}
+		order = i + 1
+		count++
+		pos++
+	}
+	if count == 0 {
+		return fail(pos)
+	}
+	return p, nil
+}
+
+//紧凑格式和中文: 数字和单位交替 如: 1d2h30m, 1 day 2 hours, 1天2小时, 一天两小时
+//开头的负号在后面的字段都没有符号时作用于所有字段 如: -1d2h, 否则只作用于第一个字段 如: -1d+2h
+func parsePeriodUnits(s string) (Period, error) {
+	type component struct {
+		unit, n, nanos, start int
+	}
+	var components []component
+	fail := func(offset int) (Period, error) {
+		return Period{}, &ParseError{Input: s, Offset: offset, Err: ErrSyntax}
+	}
+	pos := 0
+	negative, signed := false, false
+	for {
+		for pos < len(s) && (isSpace(s[pos]) || s[pos] == ',') {
+			pos++
+		}
+		if pos == len(s) {
+			break
+		}
+		start := pos
+		if len(components) == 0 {
+			negative = s[pos] == '-'
+		} else if s[pos] == '-' || s[pos] == '+' {
+			signed = true
+		}
+		n, nanos, next, fraction, ok := readPeriodNumber(s, pos, true)
+		if !ok {
+			return fail(start)
+		}
+		for pos = next; pos < len(s) && isSpace(s[pos]); pos++ {
+		}
+		var unit string
+		if pos < len(s) && isLetter(s[pos]) {
+			end := pos
+			for ; end < len(s) && isLetter(s[end]); end++ {
+			}
+			//大写M在ISO 8601中表示月, 有歧义, 只接受小写m(分钟)或mo(月)
+			if s[pos:end] == "M" {
+				return fail(pos)
+			}
+			unit = strings.ToLower(s[pos:end])
+		} else {
+			for name := range periodUnits {
+				if len(name) > len(unit) && !isLetter(name[0]) && strings.HasPrefix(s[pos:], name) {
+					unit = name
+				}
+			}
+		}
+		u, found := periodUnits[unit]
+		if !found {
+			return fail(pos)
+		}
+		if fraction && u != periodSeconds {
+			return fail(start)
+		}
+		components = append(components, component{u, n, nanos, start})
+		pos += len(unit)
+	}
+	if len(components) == 0 {
+		return fail(pos)
+	}
+	var p Period
+	for i, c := range components {
+		if i > 0 && negative && !signed {
+			c.n, c.nanos = -c.n, -c.nanos
+		}
+		if !p.addUnit(c.unit, c.n, c.nanos) {
+			return Period{}, &ParseError{Input: s, Offset: c.start, Err: ErrRange}
+		}
+	}
+	return p, nil
+}
+
+//@description: 解析时长字符串, 自动识别格式
+//              ISO 8601: P1Y2M3DT4H5M6.5S, P2W, -P1D, PT-30M
+//              紧凑格式: 1d2h30m, 1y2mo, 1.5s, 500ms, 1 day 2 hours, -1d2h(所有字段为负), 1d-2h
+//              中文: 1天2小时, 3个月, 一天两小时, 30分钟
+//              只有秒可以有小数, 紧凑格式的单位不区分大小写, 但不接受有歧义的大写M(用m表示分钟, mo表示月)
+//@param:       s string "时长字符串"
+//@return:      Period "时长"
+//@return:      error "错误信息" 无法识别时为 *ParseError
+func ParsePeriod(s string) (Period, error) {
+	pos := 0
+	if pos < len(s) && (s[pos] == '-' || s[pos] == '+') {
+		pos++
+	}
+	if pos == len(s) || (s[pos] != 'P' && s[pos] != 'p') {
+		return parsePeriodUnits(s)
+	}
+	p, err := parsePeriodISO(s, pos+1)
+	if err != nil {
+		return Period{}, err
+	}
+	if s[0] == '-' {
+		p = p.Negate()
+	}
+	return p, nil
+}
+
+//返回偏移N个月的秒级时间戳, 日超出该月天数时取月末, 时间不变
+func unixAddMonths(unix, months int64, zone TimeZone) int64 {
+	if months == 0 {
+		return unix
+	}
+	local := unix + zone.Offset()
+	days := floorDiv(local, daySec)
+	year, month, day := dayNumberDate(days)
+	total := int64(year)*12 + int64(month-1) + months
+	year, month = int(floorDiv(total, 12)), int(floorMod(total, 12))+1
+	if maxDay := GregorianCalendar.MonthDays(year, month); day > maxDay {
+		day = maxDay
+	}
+	return dateDayNumber(year, month, day)*daySec + local - days*daySec - zone.Offset()
+}
+
+//@description: 返回加上时长后的秒级时间戳, 先按日历加年和月(日超出该月天数时取月末), 再加天和时间
+//@param:       unix int64 "秒级时间戳"
+//@param:       p Period "时长"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳" 不足1秒的纳秒向下取整
+func UnixAddPeriod(unix int64, p Period, zone TimeZone) int64 {
+	unix = unixAddMonths(unix, int64(p.Years)*12+int64(p.Months), zone)
+	return unix + int64(p.Days)*daySec + int64(p.Hours)*hourSec + int64(p.Minutes)*minSec + int64(p.Seconds) + floorDiv(int64(p.Nanos), 1e9)
+}
+
+//@description: 返回在本时区加上时长后的秒级时间戳 如: 1月31日加1个月为2月的最后一天
+//@param:       p Period "时长"
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) AddPeriod(p Period) int64 {
+	return UnixAddPeriod(my.unix, p, my.zone)
+}
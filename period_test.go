@@ -0,0 +1,187 @@
+package datetime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestParsePeriod(t *testing.T) {
+	cases := []struct {
+		s    string
+		want Period
+	}{
+		{"P1Y2M3DT4H5M6.5S", Period{1, 2, 3, 4, 5, 6, 5e8}},
+		{"p1y2m3dt4h5m6,5s", Period{1, 2, 3, 4, 5, 6, 5e8}},
+		{"P2W", Period{Days: 14}},
+		{"P1W2D", Period{Days: 9}},
+		{"-P1D", Period{Days: -1}},
+		{"-P1M-1D", Period{Months: -1, Days: 1}},
+		{"PT-30M", Period{Minutes: -30}},
+		{"PT0S", Period{}},
+		{"1d2h30m", Period{Days: 1, Hours: 2, Minutes: 30}},
+		{"1y2mo", Period{Years: 1, Months: 2}},
+		{"1Y2Mo", Period{Years: 1, Months: 2}},
+		{"1.5s", Period{Seconds: 1, Nanos: 5e8}},
+		{"500ms", Period{Nanos: 500e6}},
+		{"3us 4ns", Period{Nanos: 3004}},
+		{"1 day 2 hours", Period{Days: 1, Hours: 2}},
+		{"1 day, 2 hours", Period{Days: 1, Hours: 2}},
+		{"2 weeks", Period{Days: 14}},
+		{"-1d2h", Period{Days: -1, Hours: -2}},
+		{"1d-2h", Period{Days: 1, Hours: -2}},
+		{"-1d+2h", Period{Days: -1, Hours: 2}},
+		{"0s", Period{}},
+		{"1天2小时", Period{Days: 1, Hours: 2}},
+		{"3个月", Period{Months: 3}},
+		{"一天两小时", Period{Days: 1, Hours: 2}},
+		{"30分钟", Period{Minutes: 30}},
+		{"2个星期", Period{Days: 14}},
+		{"-1年2个月", Period{Years: -1, Months: -2}},
+		{"1.5秒", Period{Seconds: 1, Nanos: 5e8}},
+		{"999999999999999999y", Period{Years: 999999999999999999}},
+	}
+	for _, c := range cases {
+		p, err := ParsePeriod(c.s)
+		if err != nil {
+			t.Errorf("ParsePeriod(%q) error: %v", c.s, err)
+			continue
+		}
+		if p != c.want {
+			t.Errorf("ParsePeriod(%q) = %+v, want %+v", c.s, p, c.want)
+		}
+	}
+}
+
+func TestParsePeriodError(t *testing.T) {
+	cases := []struct {
+		s      string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrSyntax},
+		{"P", 1, ErrSyntax},
+		{"PT", 1, ErrSyntax},
+		{"P1DT", 3, ErrSyntax},
+		{"P1D2Y", 4, ErrSyntax},
+		{"P1.5D", 4, ErrSyntax},
+		{"P1", 2, ErrSyntax},
+		{"PT1X", 3, ErrSyntax},
+		{"1x", 1, ErrSyntax},
+		{"1.5d", 0, ErrSyntax},
+		{"d", 0, ErrSyntax},
+		{"1d 2", 4, ErrSyntax},
+		{"1M", 1, ErrSyntax}, //大写M有歧义
+		{"1d2M", 3, ErrSyntax},
+		{"1000000000000000000s", 0, ErrSyntax}, //超过18位数字
+		{"999999999999999999ms", 0, ErrRange},
+		{strings.Repeat("999999999999999999s", 10), 171, ErrRange},
+	}
+	for _, c := range cases {
+		_, err := ParsePeriod(c.s)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParsePeriod(%q) error = %v, want *ParseError", c.s, err)
+			continue
+		}
+		if pe.Offset != c.offset || !errors.Is(err, c.err) {
+			t.Errorf("ParsePeriod(%q) error = %v offset %d, want %v offset %d", c.s, err, pe.Offset, c.err, c.offset)
+		}
+	}
+}
+
+func TestPeriodFormat(t *testing.T) {
+	cases := []struct {
+		p       Period
+		iso     string
+		compact string
+		chinese string
+	}{
+		{Period{}, "PT0S", "0s", "0秒"},
+		{Period{1, 2, 3, 4, 5, 6, 5e8}, "P1Y2M3DT4H5M6.5S", "1y2mo3d4h5m6.5s", "1年2个月3天4小时5分钟6.5秒"},
+		{Period{Days: 14}, "P14D", "14d", "14天"},
+		{Period{Hours: 1}, "PT1H", "1h", "1小时"},
+		{Period{Nanos: 1}, "PT0.000000001S", "0.000000001s", "0.000000001秒"},
+		{Period{Days: -1}, "-P1D", "-1d", "-1天"},
+		{Period{Days: -1, Hours: -2}, "-P1DT2H", "-1d2h", "-1天2小时"},
+		{Period{Seconds: -1, Nanos: -5e8}, "-PT1.5S", "-1.5s", "-1.5秒"},
+		{Period{Months: 1, Days: -1}, "P1M-1D", "1mo-1d", "1个月-1天"},
+		{Period{Days: -1, Hours: 2}, "P-1DT2H", "-1d+2h", "-1天+2小时"},
+		{Period{Minutes: 1, Seconds: -30}, "PT1M-30S", "1m-30s", "1分钟-30秒"},
+		{Period{Hours: -1, Nanos: 5e8}, "PT-1H0.5S", "-1h+0.5s", "-1小时+0.5秒"},
+		{Period{Minutes: 1, Nanos: -5e8}, "PT1M-0.5S", "1m-0.5s", "1分钟-0.5秒"},
+		{Period{Years: 999999999999999999, Seconds: -999999999999999999}, "P999999999999999999YT-999999999999999999S", "999999999999999999y-999999999999999999s", "999999999999999999年-999999999999999999秒"},
+	}
+	for _, c := range cases {
+		if s := c.p.String(); s != c.iso {
+			t.Errorf("%+v.String() = %q, want %q", c.p, s, c.iso)
+		}
+		if s := c.p.Compact(); s != c.compact {
+			t.Errorf("%+v.Compact() = %q, want %q", c.p, s, c.compact)
+		}
+		if s := c.p.Chinese(); s != c.chinese {
+			t.Errorf("%+v.Chinese() = %q, want %q", c.p, s, c.chinese)
+		}
+		//往返: 三种格式都能解析回原时长
+		for _, s := range []string{c.iso, c.compact, c.chinese} {
+			if p, err := ParsePeriod(s); err != nil || p != c.p {
+				t.Errorf("ParsePeriod(%q) = %+v, %v, want %+v", s, p, err, c.p)
+			}
+		}
+	}
+}
+
+func TestPeriodNormalize(t *testing.T) {
+	cases := []struct {
+		p, want Period
+	}{
+		{Period{}, Period{}},
+		{Period{Seconds: 90}, Period{Minutes: 1, Seconds: 30}},
+		{Period{Months: 14}, Period{Years: 1, Months: 2}},
+		{Period{Months: -13}, Period{Years: -1, Months: -1}},
+		{Period{Years: 1, Months: -1}, Period{Months: 11}},
+		{Period{Hours: 25}, Period{Days: 1, Hours: 1}},
+		{Period{Days: 1, Hours: -1}, Period{Hours: 23}},
+		{Period{Days: -1, Seconds: 1}, Period{Hours: -23, Minutes: -59, Seconds: -59}},
+		{Period{Months: 40, Days: 40}, Period{Years: 3, Months: 4, Days: 40}},
+		{Period{Nanos: -1}, Period{Nanos: -1}},
+		{Period{Seconds: 1, Nanos: -1}, Period{Nanos: 999999999}},
+		{Period{Seconds: 1, Nanos: 2500000000}, Period{Seconds: 3, Nanos: 5e8}},
+		{Period{Seconds: -1, Nanos: 5e8}, Period{Nanos: -5e8}},
+	}
+	for _, c := range cases {
+		if p := c.p.Normalize(); p != c.want {
+			t.Errorf("%+v.Normalize() = %+v, want %+v", c.p, p, c.want)
+		}
+	}
+}
+
+func TestUnixAddPeriod(t *testing.T) {
+	cases := []struct {
+		start string
+		p     string
+		want  string
+	}{
+		{"2020-01-31 10:00:00", "1mo", "2020-02-29 10:00:00"},
+		{"2020-01-31 10:00:00", "-1mo", "2019-12-31 10:00:00"},
+		{"2020-02-29 10:00:00", "1y", "2021-02-28 10:00:00"},
+		{"2020-03-31 10:00:00", "1mo-1d", "2020-04-29 10:00:00"},
+		{"2020-01-01 00:00:00", "P1DT1H0.5S", "2020-01-02 01:00:00"},
+		{"2020-01-01 00:00:00", "-0.5s", "2019-12-31 23:59:59"},
+	}
+	for _, c := range cases {
+		unix, err := FormatToUnix(c.start, "%Y-%m-%d %H:%M:%S", Zones.E8, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := ParsePeriod(c.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cstString(UnixAddPeriod(unix, p, Zones.E8)); got != c.want {
+			t.Errorf("%s + %s = %s, want %s", c.start, c.p, got, c.want)
+		}
+	}
+}
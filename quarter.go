@@ -0,0 +1,175 @@
+package datetime
+
+import (
+	. "github.com/jingyanbin/timezone"
+)
+
+//月份所在的季度(1-4)
+func monthQuarter(month int) int {
+	return (month-1)/3 + 1
+}
+
+//月份所在的半年(1-2)
+func monthHalfYear(month int) int {
+	return (month-1)/6 + 1
+}
+
+//返回 年,月 所在时间单位的第一个月开始的天数(含当天, 从1开始)
+func periodDay(year, month, day, months int) int {
+	pMonth := &norMonth
+	if leapYear(year) {
+		pMonth = &leapMonth
+	}
+	for i := (month - 1) / months * months; i < month-1; i++ {
+		day += pMonth[i]
+	}
+	return day
+}
+
+//@description: 返回时间戳所在的季度
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int "季度(1-4)"
+func UnixQuarter(unix int64, zone TimeZone) int {
+	_, month, _, _, _, _, _, _ := UnixToDateClock(unix, zone)
+	return monthQuarter(month)
+}
+
+//@description: 返回时间戳是所在季度的第几天
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int "天(1-92)"
+func UnixQuarterDay(unix int64, zone TimeZone) int {
+	year, month, day, _, _, _, _, _ := UnixToDateClock(unix, zone)
+	return periodDay(year, month, day, 3)
+}
+
+//@description: 返回时间戳所在季度第一天0时的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func UnixQuarterZeroHour(unix int64, zone TimeZone) int64 {
+	start, _ := UnixStartOf(unix, UnitQuarter, zone)
+	return start
+}
+
+//@description: 返回时间戳所在季度最后一秒的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func UnixQuarterEnd(unix int64, zone TimeZone) int64 {
+	end, _ := UnixEndOf(unix, UnitQuarter, zone)
+	return end
+}
+
+//@description: 返回时间戳下一个季度第一天0时的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func UnixNextQuarterZeroHour(unix int64, zone TimeZone) int64 {
+	return UnixQuarterEnd(unix, zone) + 1
+}
+
+//@description: 返回时间戳所在的半年
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int "半年(1:上半年, 2:下半年)"
+func UnixHalfYear(unix int64, zone TimeZone) int {
+	_, month, _, _, _, _, _, _ := UnixToDateClock(unix, zone)
+	return monthHalfYear(month)
+}
+
+//@description: 返回时间戳是所在半年的第几天
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int "天(1-184)"
+func UnixHalfYearDay(unix int64, zone TimeZone) int {
+	year, month, day, _, _, _, _, _ := UnixToDateClock(unix, zone)
+	return periodDay(year, month, day, 6)
+}
+
+//@description: 返回时间戳所在半年第一天0时的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func UnixHalfYearZeroHour(unix int64, zone TimeZone) int64 {
+	start, _ := UnixStartOf(unix, UnitHalfYear, zone)
+	return start
+}
+
+//@description: 返回时间戳所在半年最后一秒的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func UnixHalfYearEnd(unix int64, zone TimeZone) int64 {
+	end, _ := UnixEndOf(unix, UnitHalfYear, zone)
+	return end
+}
+
+//@description: 返回时间戳下一个半年第一天0时的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+func UnixNextHalfYearZeroHour(unix int64, zone TimeZone) int64 {
+	return UnixHalfYearEnd(unix, zone) + 1
+}
+
+//@description: 返回所在的季度
+//@return:      int "季度(1-4)"
+func (my *DateTime) Quarter() int {
+	return monthQuarter(my.month)
+}
+
+//@description: 返回是所在季度的第几天
+//@return:      int "天(1-92)"
+func (my *DateTime) QuarterDay() int {
+	return periodDay(my.year, my.month, my.day, 3)
+}
+
+//@description: 返回所在季度第一天0时的秒级时间戳
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) UnixQuarterZeroHour() int64 {
+	return unixMonthStart(my.year, (my.month-1)/3*3+1, my.zone)
+}
+
+//@description: 返回所在季度最后一秒的秒级时间戳
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) UnixQuarterEnd() int64 {
+	return my.UnixNextQuarterZeroHour() - 1
+}
+
+//@description: 返回下一个季度第一天0时的秒级时间戳
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) UnixNextQuarterZeroHour() int64 {
+	return unixMonthStart(my.year, (my.month-1)/3*3+4, my.zone)
+}
+
+//@description: 返回所在的半年
+//@return:      int "半年(1:上半年, 2:下半年)"
+func (my *DateTime) HalfYear() int {
+	return monthHalfYear(my.month)
+}
+
+//@description: 返回是所在半年的第几天
+//@return:      int "天(1-184)"
+func (my *DateTime) HalfYearDay() int {
+	return periodDay(my.year, my.month, my.day, 6)
+}
+
+//@description: 返回所在半年第一天0时的秒级时间戳
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) UnixHalfYearZeroHour() int64 {
+	return unixMonthStart(my.year, (my.month-1)/6*6+1, my.zone)
+}
+
+//@description: 返回所在半年最后一秒的秒级时间戳
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) UnixHalfYearEnd() int64 {
+	return my.UnixNextHalfYearZeroHour() - 1
+}
+
+//@description: 返回下一个半年第一天0时的秒级时间戳
+//@return:      int64 "秒级时间戳"
+func (my *DateTime) UnixNextHalfYearZeroHour() int64 {
+	return unixMonthStart(my.year, (my.month-1)/6*6+7, my.zone)
+}
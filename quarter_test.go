@@ -0,0 +1,75 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+func TestUnixQuarter(t *testing.T) {
+	cases := []struct {
+		date                     string
+		quarter, quarterDay      int
+		quarterStart, quarterEnd string
+		nextQuarter              string
+		half, halfDay            int
+		halfStart, halfEnd       string
+		nextHalf                 string
+	}{
+		{"2020-01-01", 1, 1, "2020-01-01", "2020-03-31", "2020-04-01", 1, 1, "2020-01-01", "2020-06-30", "2020-07-01"},
+		{"2020-03-31", 1, 91, "2020-01-01", "2020-03-31", "2020-04-01", 1, 91, "2020-01-01", "2020-06-30", "2020-07-01"},
+		{"2021-03-31", 1, 90, "2021-01-01", "2021-03-31", "2021-04-01", 1, 90, "2021-01-01", "2021-06-30", "2021-07-01"},
+		{"2020-05-15", 2, 45, "2020-04-01", "2020-06-30", "2020-07-01", 1, 136, "2020-01-01", "2020-06-30", "2020-07-01"},
+		{"2020-07-01", 3, 1, "2020-07-01", "2020-09-30", "2020-10-01", 2, 1, "2020-07-01", "2020-12-31", "2021-01-01"},
+		{"2020-12-31", 4, 92, "2020-10-01", "2020-12-31", "2021-01-01", 2, 184, "2020-07-01", "2020-12-31", "2021-01-01"},
+	}
+	cst := time.FixedZone("CST", 8*hourSec)
+	for _, c := range cases {
+		tm, err := time.ParseInLocation("2006-01-02 15:04:05", c.date+" 13:14:15", cst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unix := tm.Unix()
+		if q := UnixQuarter(unix, Zones.E8); q != c.quarter {
+			t.Errorf("UnixQuarter(%v) = %v, want %v", c.date, q, c.quarter)
+		}
+		if d := UnixQuarterDay(unix, Zones.E8); d != c.quarterDay {
+			t.Errorf("UnixQuarterDay(%v) = %v, want %v", c.date, d, c.quarterDay)
+		}
+		if s := UnixQuarterZeroHour(unix, Zones.E8); cstString(s) != c.quarterStart+" 00:00:00" {
+			t.Errorf("UnixQuarterZeroHour(%v) = %v, want %v", c.date, cstString(s), c.quarterStart)
+		}
+		if e := UnixQuarterEnd(unix, Zones.E8); cstString(e) != c.quarterEnd+" 23:59:59" {
+			t.Errorf("UnixQuarterEnd(%v) = %v, want %v", c.date, cstString(e), c.quarterEnd)
+		}
+		if n := UnixNextQuarterZeroHour(unix, Zones.E8); cstString(n) != c.nextQuarter+" 00:00:00" {
+			t.Errorf("UnixNextQuarterZeroHour(%v) = %v, want %v", c.date, cstString(n), c.nextQuarter)
+		}
+		if h := UnixHalfYear(unix, Zones.E8); h != c.half {
+			t.Errorf("UnixHalfYear(%v) = %v, want %v", c.date, h, c.half)
+		}
+		if d := UnixHalfYearDay(unix, Zones.E8); d != c.halfDay {
+			t.Errorf("UnixHalfYearDay(%v) = %v, want %v", c.date, d, c.halfDay)
+		}
+		if s := UnixHalfYearZeroHour(unix, Zones.E8); cstString(s) != c.halfStart+" 00:00:00" {
+			t.Errorf("UnixHalfYearZeroHour(%v) = %v, want %v", c.date, cstString(s), c.halfStart)
+		}
+		if e := UnixHalfYearEnd(unix, Zones.E8); cstString(e) != c.halfEnd+" 23:59:59" {
+			t.Errorf("UnixHalfYearEnd(%v) = %v, want %v", c.date, cstString(e), c.halfEnd)
+		}
+		if n := UnixNextHalfYearZeroHour(unix, Zones.E8); cstString(n) != c.nextHalf+" 00:00:00" {
+			t.Errorf("UnixNextHalfYearZeroHour(%v) = %v, want %v", c.date, cstString(n), c.nextHalf)
+		}
+		dt := UnixToDateTime(unix, Zones.E8)
+		if dt.Quarter() != c.quarter || dt.QuarterDay() != c.quarterDay || dt.HalfYear() != c.half || dt.HalfYearDay() != c.halfDay {
+			t.Errorf("DateTime(%v) quarter = %v %v, half-year = %v %v", c.date, dt.Quarter(), dt.QuarterDay(), dt.HalfYear(), dt.HalfYearDay())
+		}
+		if s := dt.Format("%q %Oq"); s != string(rune('0'+c.quarter))+" "+string(rune('0'+c.quarter)) {
+			t.Errorf("Format(%%q) = %q, want quarter %v", s, c.quarter)
+		}
+	}
+	if s := UnixToFormatLocale(boundaryRef, Zones.E8, "第%Oq季度", LocaleChinese); s != "第三季度" {
+		t.Errorf("FormatLocale(%%Oq) = %q, want 第三季度", s)
+	}
+}
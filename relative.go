@@ -0,0 +1,511 @@
+package datetime
+
+import (
+	"strings"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//自然语言相对日期时间表达式的语法(不区分大小写, 空白和逗号分隔, 各项依次作用于参照时间):
+//
+//	expr     = item { item }
+//	item     = "now" | "at" | day | weekday | shift | boundary | clock | meridiem
+//	day      = "today" | "tomorrow" | "yesterday"                     当天/明天/昨天 0时
+//	weekday  = ["next" | "last" | "this"] name                        name 如: monday, mon
+//	           next: 之后最近的星期几(不含当天), last: 之前最近的星期几(不含当天),
+//	           this: 本周(星期1为一周的开始)的星期几, 省略时为当天或之后最近的星期几
+//	shift    = ("+" | "-") n unit | "in" n unit | n unit ("ago" | "later")
+//	           | ("next" | "last" | "this") unit                        即 +1, -1, +0 个单位
+//	unit     = s | sec | second | m | min | minute | h | hr | hour | d | day
+//	           | w | wk | week | mo | month | y | yr | year            可加复数s
+//	boundary = ("first" | "last") "day" "of" ["next" | "last" | "this"] ("week" | "month" | "year")
+//	clock    = h [":" [mm] [":" ss]] [meridiem] | h meridiem | "noon" | "midnight"
+//	meridiem = "am" | "pm" | "night"                                  可出现在时间之前 如: 上午9点
+//	           night: 与 pm 相同, 但12点为次日0时 如: 晚上12点
+//
+//day, weekday, boundary 把时间重置为0时; 按天,周,月,年的偏移保留时间, 按月,年偏移时日超出该月天数取月末;
+//按时,分,秒的偏移和 clock 在日期确定之后生效 如: "9am tomorrow" 与 "tomorrow 9am" 相同
+//
+//中文表达式先转换为上述语法 如: 明天上午9点 -> tomorrow am 9 :, 下周三 -> +1 week this wednesday,
+//3天后 -> 3 day later, 下个月底 -> next month last day of month, 支持中文数字 如: 三天前, 九点半
+
+//中文词语对应的表达式
+var relativeChinese = relativeChineseWords()
+
+func relativeChineseWords() map[string]string {
+	words := map[string]string{
+		"现在": "now", "今天": "today", "明天": "tomorrow", "后天": "today +2 day", "大后天": "today +3 day",
+		"昨天": "yesterday", "前天": "today -2 day", "大前天": "today -3 day",
+		"上午": "am", "早上": "am", "凌晨": "am", "中午": "pm", "下午": "pm", "晚上": "night", "夜里": "night",
+		"点": ":", "时": ":", "半": "30", "分": "",
+		"秒": "second", "秒钟": "second", "分钟": "minute", "小时": "hour", "个小时": "hour", "天": "day", "日": "day",
+		"周": "week", "星期": "week", "个星期": "week", "礼拜": "week", "个礼拜": "week", "个月": "month", "年": "year",
+		"前": "ago", "以前": "ago", "之前": "ago", "后": "later", "以后": "later", "之后": "later",
+	}
+	weekdays := map[string]string{"一": "monday", "二": "tuesday", "三": "wednesday", "四": "thursday", "五": "friday", "六": "saturday", "日": "sunday", "天": "sunday"}
+	weekPrefixes := map[string]string{"": "", "本": "this week", "这": "this week", "这个": "this week", "下": "next week", "下个": "next week", "上": "last week", "上个": "last week"}
+	weekdayPrefixes := map[string]string{"": "this", "本": "this", "这": "this", "这个": "this", "下": "+1 week this", "下个": "+1 week this", "上": "-1 week this", "上个": "-1 week this"}
+	for prefix, expr := range weekPrefixes {
+		for _, week := range []string{"周", "星期", "礼拜"} {
+			if expr != "" {
+				words[prefix+week] = expr
+			}
+			for day, name := range weekdays {
+				words[prefix+week+day] = weekdayPrefixes[prefix] + " " + name
+			}
+		}
+	}
+	ends := map[string]string{"初": "first day of", "底": "last day of", "末": "last day of"}
+	for prefix, expr := range map[string]string{"月": "", "本月": "this month", "这个月": "this month", "下月": "next month", "下个月": "next month", "上月": "last month", "上个月": "last month"} {
+		if expr != "" {
+			words[prefix] = expr
+		}
+		for end, boundary := range ends {
+			words[prefix+end] = expr + " " + boundary + " month"
+		}
+	}
+	for prefix, expr := range map[string]string{"年": "", "今年": "this year", "明年": "next year", "去年": "last year"} {
+		if expr != "" {
+			words[prefix] = expr
+		}
+		for end, boundary := range ends {
+			words[prefix+end] = expr + " " + boundary + " year"
+		}
+	}
+	return words
+}
+
+//英文星期名称(含缩写)对应的星期几(1-7)
+var relativeWeekdays = map[string]int{
+	"monday": 1, "mon": 1, "tuesday": 2, "tue": 2, "tues": 2, "wednesday": 3, "wed": 3,
+	"thursday": 4, "thu": 4, "thur": 4, "thurs": 4, "friday": 5, "fri": 5, "saturday": 6, "sat": 6, "sunday": 7, "sun": 7,
+}
+
+//单位名称对应的秒数和月数, 天和周按日期偏移
+func relativeUnit(word string) (seconds int64, months int, ok bool) {
+	switch word {
+	case "s", "sec", "secs", "second", "seconds":
+		return 1, 0, true
+	case "m", "min", "mins", "minute", "minutes":
+		return minSec, 0, true
+	case "h", "hr", "hrs", "hour", "hours":
+		return hourSec, 0, true
+	case "d", "day", "days":
+		return daySec, 0, true
+	case "w", "wk", "wks", "week", "weeks":
+		return weekSec, 0, true
+	case "mo", "month", "months":
+		return 0, 1, true
+	case "y", "yr", "yrs", "year", "years":
+		return 0, 12, true
+	}
+	return 0, 0, false
+}
+
+type relativeToken struct {
+	kind  byte   //'w'单词, 'n'数字, '+', '-', ':'
+	text  string //小写的单词
+	n     int64  //数字
+	start int    //在字符串中的开始位置
+}
+
+//拆分表达式, 中文词语按最长匹配转换, 返回出错位置(-1表示成功)
+func lexRelative(s string) ([]relativeToken, int) {
+	var tokens []relativeToken
+	for pos := 0; pos < len(s); {
+		c := s[pos]
+		switch {
+		case isSpace(c) || c == ',':
+			pos++
+		case c == '+' || c == '-' || c == ':':
+			tokens = append(tokens, relativeToken{kind: c, text: s[pos : pos+1], start: pos})
+			pos++
+		case isDigit(c):
+			start := pos
+			var n int64
+			for ; pos < len(s) && isDigit(s[pos]); pos++ {
+				if pos-start == 9 {
+					return nil, start
+				}
+				n = n*10 + int64(s[pos]-'0')
+			}
+			tokens = append(tokens, relativeToken{kind: 'n', text: s[start:pos], n: n, start: start})
+		case isLetter(c):
+			start := pos
+			for ; pos < len(s) && isLetter(s[pos]); pos++ {
+			}
+			tokens = append(tokens, relativeToken{kind: 'w', text: strings.ToLower(s[start:pos]), start: start})
+		default:
+			var word string
+			for w := range relativeChinese {
+				if len(w) > len(word) && strings.HasPrefix(s[pos:], w) {
+					word = w
+				}
+			}
+			if word != "" {
+				expanded, at := lexRelative(relativeChinese[word])
+				if at >= 0 {
+					return nil, pos
+				}
+				for i := range expanded {
+					expanded[i].start = pos
+				}
+				tokens = append(tokens, expanded...)
+				pos += len(word)
+				continue
+			}
+			if n, end, ok := readChineseNumber(s, pos, len(s), 4); ok {
+				tokens = append(tokens, relativeToken{kind: 'n', text: s[pos:end], n: int64(n), start: pos})
+				pos = end
+				continue
+			}
+			return nil, pos
+		}
+	}
+	return tokens, -1
+}
+
+type relativeParser struct {
+	s        string
+	tokens   []relativeToken
+	i        int
+	zone     TimeZone
+	local    int64 //当地时间的秒数 即: unix + offset
+	shift    int64 //按时,分,秒的偏移
+	clockAt  int   //时间所在的token, -1表示没有
+	hour     int
+	min      int
+	sec      int
+	meridiem int //0: 无, 1: am, 2: pm, 3: night
+}
+
+func (my *relativeParser) error(i int, err error) *ParseError {
+	if i >= len(my.tokens) {
+		return &ParseError{Input: my.s, Offset: len(my.s), Err: err}
+	}
+	return &ParseError{Input: my.s, Offset: my.tokens[i].start, Directive: my.tokens[i].text, Err: err}
+}
+
+//第i个token是否为指定单词
+func (my *relativeParser) word(i int, words ...string) bool {
+	if i >= len(my.tokens) || my.tokens[i].kind != 'w' {
+		return false
+	}
+	for _, w := range words {
+		if my.tokens[i].text == w {
+			return true
+		}
+	}
+	return false
+}
+
+func (my *relativeParser) unix() int64 {
+	return my.local - my.zone.Offset()
+}
+
+func (my *relativeParser) setUnix(unix int64) {
+	my.local = unix + my.zone.Offset()
+}
+
+//日期变为当天0时之后 days 天
+func (my *relativeParser) setDays(days int) {
+	unix, _ := UnixDayZeroHourNext(my.unix(), days, 0, 0, 0, my.zone)
+	my.setUnix(unix)
+}
+
+//偏移 n 个单位, 按月偏移时日超出该月天数取月末
+func (my *relativeParser) add(n int64, seconds int64, months int) {
+	switch {
+	case months != 0:
+		my.setUnix(unixAddMonths(my.unix(), n*int64(months), my.zone))
+	case seconds >= daySec:
+		my.local += n * seconds
+	default:
+		my.shift += n * seconds
+	}
+}
+
+//星期几: mode 'n'之后最近(不含当天), 'l'之前最近(不含当天), 't'本周, 0当天或之后最近
+func (my *relativeParser) weekday(week int, mode byte) {
+	start := UnixDayZeroHour(my.unix(), my.zone)
+	var unix int64
+	switch mode {
+	case 'n':
+		unix, _ = UnixFutureWeekDayA(start, week, 0, 0, 0, my.zone)
+	case 'l':
+		unix, _ = UnixFutureWeekDayA(start-8*daySec, week, 0, 0, 0, my.zone)
+	case 't':
+		unix, _ = UnixStartOfWeek(start, 1, my.zone)
+		unix += int64(week-1) * daySec
+	default:
+		unix, _ = UnixFutureWeekDayA(start-daySec, week, 0, 0, 0, my.zone)
+	}
+	my.setUnix(unix)
+}
+
+//周,月,年的第一天或最后一天
+func (my *relativeParser) boundary(first bool, unit string) {
+	unix := my.unix()
+	switch unit {
+	case "week":
+		if first {
+			unix, _ = UnixStartOfWeek(unix, 1, my.zone)
+		} else {
+			unix, _ = UnixEndOfWeek(unix, 1, my.zone)
+		}
+	default:
+		year, month, _ := dayNumberDate(floorDiv(my.local, daySec))
+		if unit == "year" {
+			month = 1
+		}
+		if first {
+			unix = unixMonthStart(year, month, my.zone)
+		} else if unit == "year" {
+			unix = unixMonthStart(year+1, 1, my.zone) - daySec
+		} else {
+			unix = unixMonthStart(year, month+1, my.zone) - daySec
+		}
+	}
+	my.setUnix(UnixDayZeroHour(unix, my.zone))
+}
+
+//next, last, this 对应的偏移数量
+func relativeDirection(word string) int64 {
+	switch word {
+	case "next":
+		return 1
+	case "last":
+		return -1
+	}
+	return 0
+}
+
+//n unit [ago|later], sign 为 1 或 -1, 不需要 ago/later 时 suffix 为 false
+func (my *relativeParser) parseShift(sign int64, suffix bool) error {
+	if my.i >= len(my.tokens) || my.tokens[my.i].kind != 'n' {
+		return my.error(my.i, ErrSyntax)
+	}
+	n := my.tokens[my.i].n
+	my.i++
+	if my.i >= len(my.tokens) || my.tokens[my.i].kind != 'w' {
+		return my.error(my.i, ErrSyntax)
+	}
+	seconds, months, ok := relativeUnit(my.tokens[my.i].text)
+	if !ok {
+		return my.error(my.i, ErrSyntax)
+	}
+	my.i++
+	if suffix {
+		switch {
+		case my.word(my.i, "ago"):
+			sign = -1
+		case my.word(my.i, "later", "after", "hence"):
+			sign = 1
+		default:
+			return my.error(my.i, ErrSyntax)
+		}
+		my.i++
+	}
+	my.add(sign*n, seconds, months)
+	return nil
+}
+
+//h [":" [mm] [":" ss]] [am|pm]
+func (my *relativeParser) parseClock() error {
+	at := my.i
+	if my.clockAt >= 0 {
+		return my.error(at, ErrSyntax)
+	}
+	my.hour = int(my.tokens[my.i].n)
+	my.min, my.sec = 0, 0
+	my.i++
+	colon := my.i < len(my.tokens) && my.tokens[my.i].kind == ':'
+	if colon {
+		my.i++
+		if my.i < len(my.tokens) && my.tokens[my.i].kind == 'n' {
+			my.min = int(my.tokens[my.i].n)
+			my.i++
+			if my.i+1 < len(my.tokens) && my.tokens[my.i].kind == ':' && my.tokens[my.i+1].kind == 'n' {
+				my.sec = int(my.tokens[my.i+1].n)
+				my.i += 2
+			}
+		}
+	}
+	if my.word(my.i, "am", "pm", "night") {
+		my.parseMeridiem()
+	} else if !colon && my.meridiem == 0 {
+		return my.error(at, ErrSyntax)
+	}
+	my.clockAt = at
+	return nil
+}
+
+func (my *relativeParser) parseMeridiem() {
+	switch my.tokens[my.i].text {
+	case "am":
+		my.meridiem = 1
+	case "pm":
+		my.meridiem = 2
+	default:
+		my.meridiem = 3
+	}
+	my.i++
+}
+
+func (my *relativeParser) parse() error {
+	for my.i < len(my.tokens) {
+		token := my.tokens[my.i]
+		switch token.kind {
+		case 'n':
+			if my.i+1 < len(my.tokens) && my.tokens[my.i+1].kind == 'w' {
+				if _, _, ok := relativeUnit(my.tokens[my.i+1].text); ok {
+					if err := my.parseShift(1, true); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			if err := my.parseClock(); err != nil {
+				return err
+			}
+			continue
+		case '+', '-':
+			my.i++
+			sign := int64(1)
+			if token.kind == '-' {
+				sign = -1
+			}
+			if err := my.parseShift(sign, false); err != nil {
+				return err
+			}
+			continue
+		case ':':
+			return my.error(my.i, ErrSyntax)
+		}
+
+		my.i++
+		if week, ok := relativeWeekdays[token.text]; ok {
+			my.weekday(week, 0)
+			continue
+		}
+		switch token.text {
+		case "now", "at":
+		case "today":
+			my.setDays(0)
+		case "tomorrow":
+			my.setDays(1)
+		case "yesterday":
+			my.setDays(-1)
+		case "noon", "midnight":
+			if my.clockAt >= 0 {
+				return my.error(my.i-1, ErrSyntax)
+			}
+			my.clockAt = my.i - 1
+			my.hour, my.min, my.sec = 0, 0, 0
+			if token.text == "noon" {
+				my.hour = 12
+			}
+		case "am", "pm", "night":
+			my.i--
+			my.parseMeridiem()
+		case "in":
+			if err := my.parseShift(1, false); err != nil {
+				return err
+			}
+		case "first", "last", "next", "this":
+			if (token.text == "first" || token.text == "last") && my.word(my.i, "day") && my.word(my.i+1, "of") {
+				my.i += 2
+				if my.word(my.i, "next", "last", "this") {
+					direction := relativeDirection(my.tokens[my.i].text)
+					my.i++
+					if !my.word(my.i, "week", "month", "year") {
+						return my.error(my.i, ErrSyntax)
+					}
+					seconds, months, _ := relativeUnit(my.tokens[my.i].text)
+					my.add(direction, seconds, months)
+				}
+				if !my.word(my.i, "week", "month", "year") {
+					return my.error(my.i, ErrSyntax)
+				}
+				my.boundary(token.text == "first", my.tokens[my.i].text)
+				my.i++
+				continue
+			}
+			if token.text == "first" || my.i >= len(my.tokens) || my.tokens[my.i].kind != 'w' {
+				return my.error(my.i-1, ErrSyntax)
+			}
+			if week, ok := relativeWeekdays[my.tokens[my.i].text]; ok {
+				my.weekday(week, token.text[0])
+				my.i++
+				continue
+			}
+			seconds, months, ok := relativeUnit(my.tokens[my.i].text)
+			if !ok {
+				return my.error(my.i, ErrSyntax)
+			}
+			my.add(relativeDirection(token.text), seconds, months)
+			my.i++
+		default:
+			return my.error(my.i-1, ErrSyntax)
+		}
+	}
+
+	if my.meridiem != 0 && my.clockAt < 0 {
+		return my.error(len(my.tokens), ErrMissing)
+	}
+	if my.clockAt >= 0 {
+		var days int64
+		if my.meridiem != 0 {
+			if my.hour < 1 || my.hour > 12 {
+				return my.error(my.clockAt, newRangeError("hour", int64(my.hour), 1, 12))
+			}
+			switch {
+			case my.meridiem == 3 && my.hour == 12:
+				//晚上12点为次日0时
+				my.hour, days = 0, 1
+			case my.meridiem >= 2 && my.hour < 12:
+				my.hour += 12
+			case my.meridiem == 1 && my.hour == 12:
+				my.hour = 0
+			}
+		}
+		if err := checkClock(my.hour, my.min, my.sec); err != nil {
+			return my.error(my.clockAt, err)
+		}
+		my.local = (floorDiv(my.local, daySec)+days)*daySec + int64(my.hour*hourSec+my.min*minSec+my.sec)
+	}
+	my.local += my.shift
+	return nil
+}
+
+//@description: 解析相对于参照时间的自然语言日期时间表达式 -> 秒级时间戳
+//              如: tomorrow 9am, next monday, +3d, in 2 hours, 3 days ago, last day of month,
+//              明天上午9点, 下周三, 3天后, 下个月底, 语法见本文件开头的说明
+//@param:       s string "表达式" 不区分大小写
+//@param:       ref int64 "参照的秒级时间戳" 一般为当前时间
+//@param:       zone TimeZone "时区"
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息" 无法识别时为 *ParseError
+func ParseRelativeUnix(s string, ref int64, zone TimeZone) (int64, error) {
+	tokens, at := lexRelative(s)
+	if at >= 0 {
+		return 0, &ParseError{Input: s, Offset: at, Err: ErrSyntax}
+	}
+	if len(tokens) == 0 {
+		return 0, &ParseError{Input: s, Offset: 0, Err: ErrSyntax}
+	}
+	parser := relativeParser{s: s, tokens: tokens, zone: zone, local: ref + zone.Offset(), clockAt: -1}
+	if err := parser.parse(); err != nil {
+		return 0, err
+	}
+	return parser.unix(), nil
+}
+
+//@description: 解析相对于参照时间的自然语言日期时间表达式 -> 秒级时间戳
+//@param:       s string "表达式" 如: tomorrow 9am, 明天上午9点
+//@param:       ref *DateTime "参照的时间" 使用其时区
+//@return:      int64 "秒级时间戳"
+//@return:      error "错误信息"
+func ParseRelative(s string, ref *DateTime) (int64, error) {
+	return ParseRelativeUnix(s, ref.unix, ref.zone)
+}
@@ -0,0 +1,144 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//参照时间: 2020-09-16 14:30:15 星期三(北京时间)
+const relativeRef = 1600237815
+
+func TestParseRelativeUnix(t *testing.T) {
+	cases := []struct {
+		s    string
+		want string //北京时间
+	}{
+		//now, at, day
+		{"now", "2020-09-16 14:30:15"},
+		{"today", "2020-09-16 00:00:00"},
+		{"tomorrow", "2020-09-17 00:00:00"},
+		{"Yesterday", "2020-09-15 00:00:00"},
+		//weekday
+		{"wednesday", "2020-09-16 00:00:00"},
+		{"monday", "2020-09-21 00:00:00"},
+		{"fri", "2020-09-18 00:00:00"},
+		{"next wednesday", "2020-09-23 00:00:00"},
+		{"next mon", "2020-09-21 00:00:00"},
+		{"last wednesday", "2020-09-09 00:00:00"},
+		{"last friday", "2020-09-11 00:00:00"},
+		{"this monday", "2020-09-14 00:00:00"},
+		{"this sunday", "2020-09-20 00:00:00"},
+		//shift
+		{"+3d", "2020-09-19 14:30:15"},
+		{"-2 hours", "2020-09-16 12:30:15"},
+		{"+90 s", "2020-09-16 14:31:45"},
+		{"+5 min", "2020-09-16 14:35:15"},
+		{"in 2 hours", "2020-09-16 16:30:15"},
+		{"3 days ago", "2020-09-13 14:30:15"},
+		{"2 weeks later", "2020-09-30 14:30:15"},
+		{"+1 month", "2020-10-16 14:30:15"},
+		{"+1y", "2021-09-16 14:30:15"},
+		{"next month", "2020-10-16 14:30:15"},
+		{"last year", "2019-09-16 14:30:15"},
+		{"this week", "2020-09-16 14:30:15"},
+		{"last day of month +5 months", "2021-02-28 00:00:00"},
+		//boundary
+		{"first day of month", "2020-09-01 00:00:00"},
+		{"last day of month", "2020-09-30 00:00:00"},
+		{"first day of week", "2020-09-14 00:00:00"},
+		{"last day of week", "2020-09-20 00:00:00"},
+		{"first day of next month", "2020-10-01 00:00:00"},
+		{"last day of last month", "2020-08-31 00:00:00"},
+		{"first day of this year", "2020-01-01 00:00:00"},
+		{"last day of year", "2020-12-31 00:00:00"},
+		//clock, meridiem
+		{"9am", "2020-09-16 09:00:00"},
+		{"9:30", "2020-09-16 09:30:00"},
+		{"9:", "2020-09-16 09:00:00"},
+		{"9:30:15 pm", "2020-09-16 21:30:15"},
+		{"12am", "2020-09-16 00:00:00"},
+		{"12pm", "2020-09-16 12:00:00"},
+		{"noon", "2020-09-16 12:00:00"},
+		{"midnight", "2020-09-16 00:00:00"},
+		{"at 5pm", "2020-09-16 17:00:00"},
+		{"pm 3:", "2020-09-16 15:00:00"},
+		//组合
+		{"tomorrow 9am", "2020-09-17 09:00:00"},
+		{"9am tomorrow", "2020-09-17 09:00:00"},
+		{"tomorrow, noon", "2020-09-17 12:00:00"},
+		{"next friday 6pm", "2020-09-18 18:00:00"},
+		{"tomorrow 9am +30 min", "2020-09-17 09:30:00"},
+		//中文
+		{"现在", "2020-09-16 14:30:15"},
+		{"后天", "2020-09-18 00:00:00"},
+		{"明天上午9点", "2020-09-17 09:00:00"},
+		{"下午3点", "2020-09-16 15:00:00"},
+		{"晚上8点", "2020-09-16 20:00:00"},
+		{"晚上12点", "2020-09-17 00:00:00"},
+		{"明天晚上12点", "2020-09-18 00:00:00"},
+		{"夜里11点半", "2020-09-16 23:30:00"},
+		{"中午12点", "2020-09-16 12:00:00"},
+		{"凌晨12点", "2020-09-16 00:00:00"},
+		{"tomorrow 12 night", "2020-09-18 00:00:00"},
+		{"九点半", "2020-09-16 09:30:00"},
+		{"下周三", "2020-09-23 00:00:00"},
+		{"上周五", "2020-09-11 00:00:00"},
+		{"本周日", "2020-09-20 00:00:00"},
+		{"3天后", "2020-09-19 14:30:15"},
+		{"三天前", "2020-09-13 14:30:15"},
+		{"2个小时后", "2020-09-16 16:30:15"},
+		{"下个月底", "2020-10-31 00:00:00"},
+		{"今年底", "2020-12-31 00:00:00"},
+	}
+	cst := time.FixedZone("CST", 8*hourSec)
+	for _, c := range cases {
+		unix, err := ParseRelativeUnix(c.s, relativeRef, Zones.E8)
+		if err != nil {
+			t.Errorf("ParseRelativeUnix(%q) error: %v", c.s, err)
+			continue
+		}
+		if got := time.Unix(unix, 0).In(cst).Format("2006-01-02 15:04:05"); got != c.want {
+			t.Errorf("ParseRelativeUnix(%q) = %v, want %v", c.s, got, c.want)
+		}
+	}
+}
+
+func TestParseRelativeUnixError(t *testing.T) {
+	cases := []struct {
+		s   string
+		err error
+	}{
+		{"", ErrSyntax},
+		{"foo", ErrSyntax},
+		{"next", ErrSyntax},
+		{"next foo", ErrSyntax},
+		{"first monday", ErrSyntax},
+		{"+3", ErrSyntax},
+		{"+3 foo", ErrSyntax},
+		{"3 days", ErrSyntax},
+		{"in days", ErrSyntax},
+		{"1234567890 days ago", ErrSyntax},
+		{":", ErrSyntax},
+		{"9", ErrSyntax},
+		{"9am 10am", ErrSyntax},
+		{"noon midnight", ErrSyntax},
+		{"last day of", ErrSyntax},
+		{"last day of next foo", ErrSyntax},
+		{"明天#", ErrSyntax},
+		{"pm", ErrMissing},
+		{"25:00", ErrRange},
+		{"9:60", ErrRange},
+		{"13pm", ErrRange},
+		{"0am", ErrRange},
+	}
+	for _, c := range cases {
+		_, err := ParseRelativeUnix(c.s, relativeRef, Zones.E8)
+		var pErr *ParseError
+		if !errors.As(err, &pErr) || !errors.Is(err, c.err) {
+			t.Errorf("ParseRelativeUnix(%q) error = %v, want %v", c.s, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package datetime
+
+import (
+	"math"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//二十四节气名称, 按公历年中的顺序, 小寒(太阳黄经285度)为第0个, 每个相隔15度
+var solarTermNames = [24]string{
+	"小寒", "大寒", "立春", "雨水", "惊蛰", "春分", "清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+	"小暑", "大暑", "立秋", "处暑", "白露", "秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+}
+
+const degToRad = math.Pi / 180
+const jdUnixEpoch = 2440587.5 //1970年1月1日0时的儒略日
+const jdJ2000 = 2451545.0     //2000年1月1日12时(TT)的儒略日
+
+//地球日心黄经 VSOP87 截断项(L0-L5): 振幅(1e-8弧度), 相位, 频率
+var vsopEarthL = [][][3]float64{
+	{ //L0
+		{175347046, 0, 0}, {3341656, 4.6692568, 6283.0758500}, {34894, 4.62610, 12566.15170}, {3497, 2.7441, 5753.3849}, {3418, 2.8289, 3.5231},
+		{3136, 3.6277, 77713.7715}, {2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097}, {1324, 0.7425, 11506.7698}, {1273, 2.0371, 529.6910},
+		{1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927}, {902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694},
+		{753, 2.533, 5507.553}, {505, 4.583, 18849.228}, {492, 4.205, 775.523}, {357, 2.920, 0.067}, {317, 5.849, 11790.629},
+		{284, 1.899, 796.298}, {271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314}, {205, 1.869, 5573.143},
+		{202, 2.458, 6069.777}, {156, 0.833, 213.299}, {132, 3.411, 2942.463}, {126, 1.083, 20.775}, {115, 0.645, 0.980},
+		{103, 0.636, 4694.003}, {102, 0.976, 15720.839}, {102, 4.267, 7.114}, {99, 6.21, 2146.17}, {98, 0.68, 155.42},
+		{86, 5.98, 161000.69}, {85, 1.30, 6275.96}, {85, 3.67, 71430.70}, {80, 1.81, 17260.15}, {79, 3.04, 12036.46},
+		{75, 1.76, 5088.63}, {74, 3.50, 3154.69}, {74, 4.68, 801.82}, {70, 0.83, 9437.76}, {62, 3.98, 8827.39},
+		{61, 1.82, 7084.90}, {57, 2.78, 6286.60}, {56, 4.39, 14143.50}, {56, 3.47, 6279.55}, {52, 0.19, 12139.55},
+		{52, 1.33, 1748.02}, {51, 0.28, 5856.48}, {49, 0.49, 1194.45}, {41, 5.37, 8429.24}, {41, 2.40, 19651.05},
+		{39, 6.17, 10447.39}, {37, 6.04, 10213.29}, {37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+		{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87}, {25, 3.16, 4690.48},
+	},
+	{ //L1
+		{628331966747, 0, 0}, {206059, 2.678235, 6283.075850}, {4303, 2.6351, 12566.1517}, {425, 1.590, 3.523}, {119, 5.796, 26.298},
+		{109, 2.966, 1577.344}, {93, 2.59, 18849.23}, {72, 1.14, 529.69}, {68, 1.87, 398.15}, {67, 4.41, 5507.55},
+		{59, 2.89, 5223.69}, {56, 2.17, 155.42}, {45, 0.40, 796.30}, {36, 0.47, 775.52}, {29, 2.65, 7.11},
+		{21, 5.34, 0.98}, {19, 1.85, 5486.78}, {19, 4.97, 213.30}, {17, 2.99, 6275.96}, {16, 0.03, 2544.31},
+		{16, 1.43, 2146.17}, {15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63}, {12, 5.27, 1194.45},
+		{12, 2.08, 4694.00}, {11, 0.77, 553.57}, {10, 1.30, 6286.60}, {10, 4.24, 1349.87}, {9, 2.70, 242.73},
+		{9, 5.64, 951.72}, {8, 5.30, 2352.87}, {6, 2.65, 9437.76}, {6, 4.67, 4690.48},
+	},
+	{ //L2
+		{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152}, {27, 0.05, 3.52}, {16, 5.19, 26.30},
+		{16, 3.68, 155.42}, {10, 0.76, 18849.23}, {9, 2.06, 77713.77}, {7, 0.83, 775.52}, {5, 4.66, 1577.34},
+		{4, 1.03, 7.11}, {4, 3.44, 5573.14}, {3, 5.14, 796.30}, {3, 6.05, 5507.55}, {3, 1.19, 242.73},
+		{3, 6.12, 529.69}, {3, 0.31, 398.15}, {3, 2.28, 553.57}, {2, 4.38, 5223.69}, {2, 3.75, 0.98},
+	},
+	{ //L3
+		{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15}, {3, 5.20, 155.42}, {1, 4.72, 3.52},
+		{1, 5.30, 18849.23}, {1, 5.97, 242.73},
+	},
+	{ //L4
+		{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+	},
+	{ //L5
+		{1, 3.14, 0},
+	},
+}
+
+//地球日地距离 VSOP87 截断项(R0)
+var vsopEarthR0 = [][3]float64{
+	{100013989, 0, 0}, {1670700, 3.0984635, 6283.0758500}, {13956, 3.05525, 12566.15170},
+	{3084, 5.1985, 77713.7715}, {1628, 1.1739, 5753.3849}, {1576, 2.8469, 7860.4194},
+}
+
+//@description: ΔT = TT - UT (秒), Espenak & Meeus 多项式
+//@param:       year float64 "年(小数)"
+//@return:      float64 "秒"
+func deltaT(year float64) float64 {
+	switch {
+	case year < -500:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	case year < 500:
+		u := year / 100
+		return 10583.6 + u*(-1014.41+u*(33.78311+u*(-5.952053+u*(-0.1798452+u*(0.022174192+u*0.0090316521)))))
+	case year < 1600:
+		u := (year - 1000) / 100
+		return 1574.2 + u*(-556.01+u*(71.23472+u*(0.319781+u*(-0.8503463+u*(-0.005050998+u*0.0083572073)))))
+	case year < 1700:
+		t := year - 1600
+		return 120 + t*(-0.9808+t*(-0.01532+t/7129))
+	case year < 1800:
+		t := year - 1700
+		return 8.83 + t*(0.1603+t*(-0.0059285+t*(0.00013336-t/1174000)))
+	case year < 1860:
+		t := year - 1800
+		return 13.72 + t*(-0.332447+t*(0.0068612+t*(0.0041116+t*(-0.00037436+t*(0.0000121272+t*(-0.0000001699+t*0.000000000875))))))
+	case year < 1900:
+		t := year - 1860
+		return 7.62 + t*(0.5737+t*(-0.251754+t*(0.01680668+t*(-0.0004473624+t/233174))))
+	case year < 1920:
+		t := year - 1900
+		return -2.79 + t*(1.494119+t*(-0.0598939+t*(0.0061966-t*0.000197)))
+	case year < 1941:
+		t := year - 1920
+		return 21.20 + t*(0.84493+t*(-0.076100+t*0.0020936))
+	case year < 1961:
+		t := year - 1950
+		return 29.07 + t*(0.407+t*(-1.0/233+t/2547))
+	case year < 1986:
+		t := year - 1975
+		return 45.45 + t*(1.067+t*(-1.0/260-t/718))
+	case year < 2005:
+		t := year - 2000
+		return 63.86 + t*(0.3345+t*(-0.060374+t*(0.0017275+t*(0.000651814+t*0.00002373599))))
+	case year < 2050:
+		t := year - 2000
+		return 62.92 + t*(0.32217+t*0.005589)
+	case year < 2150:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-year)
+	}
+	u := (year - 1820) / 100
+	return -20 + 32*u*u
+}
+
+//VSOP87 截断项求和
+func vsopSum(terms [][3]float64, tau float64) (sum float64) {
+	for _, t := range terms {
+		sum += t[0] * math.Cos(t[1]+t[2]*tau)
+	}
+	return
+}
+
+//@description: 太阳视黄经(度), 含FK5修正, 章动, 光行差
+//@param:       jde float64 "儒略日(TT)"
+//@return:      float64 "黄经[0, 360)"
+func sunApparentLongitude(jde float64) float64 {
+	tau := (jde - jdJ2000) / 365250
+	var l float64
+	for i := len(vsopEarthL) - 1; i >= 0; i-- {
+		l = l*tau + vsopSum(vsopEarthL[i], tau)
+	}
+	l /= 1e8
+	r := vsopSum(vsopEarthR0, tau) / 1e8
+	lon := l/degToRad + 180 - 0.09033/3600
+	t := tau * 10
+	omega := (125.04452 - 1934.136261*t) * degToRad
+	ls := (280.4665 + 36000.7698*t) * degToRad
+	lm := (218.3165 + 481267.8813*t) * degToRad
+	nutation := -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+	lon += nutation/3600 - 20.4898/3600/r
+	lon = math.Mod(lon, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon
+}
+
+//太阳视黄经到达 lon 度的儒略日(TT), guess 为附近的初始值
+func sunLongitudeJDE(lon, guess float64) float64 {
+	jde := guess
+	for i := 0; i < 20; i++ {
+		diff := math.Mod(lon-sunApparentLongitude(jde)+540, 360) - 180
+		jde += diff * 365.2422 / 360
+		if math.Abs(diff) < 1e-8 {
+			break
+		}
+	}
+	return jde
+}
+
+//儒略日(TT) -> 秒级时间戳(UT)
+func jdeToUnix(jde float64) int64 {
+	year := 2000 + (jde-jdJ2000)/365.25
+	return int64(math.Floor(((jde-jdUnixEpoch)*daySec - deltaT(year)) + 0.5))
+}
+
+//节气
+type SolarTerm struct {
+	Year  int    //公历年
+	Index int    //年中序号(0-23), 0为小寒, 23为冬至
+	Name  string //名称
+	Unix  int64  //交节时刻的秒级时间戳
+}
+
+//公历年中第 index 个节气的交节时刻
+func solarTerm(year, index int) SolarTerm {
+	for index < 0 {
+		year, index = year-1, index+24
+	}
+	for index > 23 {
+		year, index = year+1, index-24
+	}
+	lon := float64((285 + index*15) % 360)
+	//小寒约在1月6日, 之后每个节气约相隔15.22天
+	guess := jdUnixEpoch + float64(dateDayNumber(year, 1, 6)) + float64(index)*15.2184
+	return SolarTerm{Year: year, Index: index, Name: solarTermNames[index], Unix: jdeToUnix(sunLongitudeJDE(lon, guess))}
+}
+
+//@description: 返回公历年的二十四节气交节时刻, 由太阳视黄经计算
+//              2000-2025年与香港天文台,紫金山天文台公布的交节时刻相差在1分钟以内, 年份越远ΔT的误差越大
+//@param:       year int "公历年(1-9999)"
+//@return:      [24]SolarTerm "节气" 按年中顺序, 小寒在前, 冬至在后
+//@return:      error "错误信息"
+func SolarTermsOfYear(year int) (terms [24]SolarTerm, err error) {
+	if year < 1 || year > 9999 {
+		return terms, newRangeError("year", int64(year), 1, 9999)
+	}
+	for i := 0; i < 24; i++ {
+		terms[i] = solarTerm(year, i)
+	}
+	return
+}
+
+//@description: 返回时间戳所在的节气和下一个节气
+//              按当地日期比较: 交节当天即为该节气
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      current SolarTerm "当前节气"
+//@return:      next SolarTerm "下一个节气"
+func SolarTermOf(unix int64, zone TimeZone) (current, next SolarTerm) {
+	days := localDayNumber(unix, zone)
+	year, month, _ := dayNumberDate(days)
+	//当地次日0时的太阳视黄经决定当天已经过的最后一个节气
+	end := float64((days+1)*daySec-zone.Offset())/daySec + jdUnixEpoch
+	lon := sunApparentLongitude(end + deltaT(float64(year))/daySec)
+	index := int(math.Mod(lon+360-285, 360) / 15)
+	if month == 1 && index > 12 {
+		//1月上旬还在上一年的冬至
+		year--
+	}
+	current = solarTerm(year, index)
+	if localDayNumber(current.Unix, zone) > days {
+		//交节时刻恰在0时附近的浮点误差
+		current = solarTerm(year, index-1)
+	}
+	return current, solarTerm(current.Year, current.Index+1)
+}
+
+//@description: 返回当前节气和下一个节气
+//@return:      current SolarTerm "当前节气"
+//@return:      next SolarTerm "下一个节气"
+func (my *DateTime) SolarTerm() (current, next SolarTerm) {
+	return SolarTermOf(my.unix, my.zone)
+}
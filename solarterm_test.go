@@ -0,0 +1,73 @@
+package datetime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//香港天文台,紫金山天文台公布的交节时刻(北京时间, 精确到分)
+var publishedSolarTerms = []struct {
+	year  int
+	index int
+	time  string
+}{
+	{2000, 5, "2000-03-20 15:35"}, {2000, 11, "2000-06-21 09:48"}, {2000, 23, "2000-12-21 21:37"},
+	{2020, 5, "2020-03-20 11:50"}, {2020, 11, "2020-06-21 05:44"}, {2020, 17, "2020-09-22 21:31"}, {2020, 23, "2020-12-21 18:02"},
+	{2022, 2, "2022-02-04 04:51"},
+	{2023, 2, "2023-02-04 10:42"}, {2023, 5, "2023-03-21 05:24"}, {2023, 11, "2023-06-21 22:57"}, {2023, 17, "2023-09-23 14:50"}, {2023, 23, "2023-12-22 11:27"},
+	{2024, 2, "2024-02-04 16:27"}, {2024, 5, "2024-03-20 11:06"}, {2024, 11, "2024-06-21 04:51"}, {2024, 17, "2024-09-22 20:44"}, {2024, 23, "2024-12-21 17:20"},
+	{2025, 2, "2025-02-03 22:10"}, {2025, 5, "2025-03-20 17:01"}, {2025, 11, "2025-06-21 10:42"}, {2025, 17, "2025-09-23 02:19"}, {2025, 23, "2025-12-21 23:03"},
+}
+
+func TestSolarTermsOfYear(t *testing.T) {
+	cst := time.FixedZone("CST", 8*3600)
+	for _, c := range publishedSolarTerms {
+		want, err := time.ParseInLocation("2006-01-02 15:04", c.time, cst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		terms, err := SolarTermsOfYear(c.year)
+		if err != nil {
+			t.Fatal(err)
+		}
+		term := terms[c.index]
+		//公布的时刻精确到分, 允许1分钟误差
+		if diff := term.Unix - want.Unix(); diff < -60 || diff > 60 {
+			t.Errorf("%v %v: got %v, want %v, diff=%vs", c.year, term.Name, time.Unix(term.Unix, 0).In(cst), c.time, diff)
+		}
+	}
+	if _, err := SolarTermsOfYear(0); !errors.Is(err, ErrRange) {
+		t.Errorf("year 0: error = %v, want ErrRange", err)
+	}
+	if _, err := SolarTermsOfYear(10000); !errors.Is(err, ErrRange) {
+		t.Errorf("year 10000: error = %v, want ErrRange", err)
+	}
+}
+
+func TestSolarTermOf(t *testing.T) {
+	cases := []struct {
+		date, current, next string
+	}{
+		{"2024/02/04 00:00:00", "立春", "雨水"}, //交节当天
+		{"2024/02/03 23:59:59", "大寒", "立春"},
+		{"2024/01/03 12:00:00", "冬至", "小寒"}, //上一年的冬至
+		{"2024/12/31 12:00:00", "冬至", "小寒"},
+		{"2025/12/21 00:00:00", "冬至", "小寒"},
+	}
+	for _, c := range cases {
+		unix, err := YmdHMSToUnix(c.date, Zones.E8, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		current, next := SolarTermOf(unix, Zones.E8)
+		if current.Name != c.current || next.Name != c.next || next.Unix <= current.Unix {
+			t.Errorf("%v: got %v %v, want %v %v", c.date, current.Name, next.Name, c.current, c.next)
+		}
+		if localDayNumber(current.Unix, Zones.E8) > localDayNumber(unix, Zones.E8) || next.Unix <= unix {
+			t.Errorf("%v: %v not before %v", c.date, current.Name, c.date)
+		}
+	}
+}
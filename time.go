@@ -1,9 +1,8 @@
 package datetime
 
 import (
-	. "github.com/jingyanbin/basal"
 	. "github.com/jingyanbin/timezone"
-	"strconv"
+	"math"
 	_ "unsafe"
 )
 
@@ -13,10 +12,11 @@ const hourSec = 3600
 const daySec = 3600 * 24 //每天的秒数
 const weekSec = 3600 * 24 * 7
 
-const firstYears = 365
-const secondYears = 365 + 365
-const thirdYears = 365 + 365 + 366
-const fourYears = 365 + 365 + 366 + 365 //每个四年的总天数
+//秒级时间戳(int64)可表示的年份范围, 使用天文纪年: 公元前1年为0年
+const minYear = -292277022657
+const maxYear = 292277026596
+const minDayNumber = math.MinInt64/daySec + 1 //留出一天用于时区偏移
+const maxDayNumber = math.MaxInt64/daySec - 1
 
 var norMonth = [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}  //平年
 var leapMonth = [12]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31} //闰年
@@ -26,54 +26,68 @@ func leapYear(year int) bool {
 	return (year%4 == 0 && year%100 != 0) || year%400 == 0
 }
 
+//年,月,日 -> 1970年1月1日以来的天数(公历, 与时区无关)
+func dateDayNumber(year, month, day int) int64 {
+	y := int64(year)
+	if month <= 2 {
+		y -= 1
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400        //[0, 399]
+	mp := int64(month+9) % 12 //3月为0
+	doy := (153*mp+2)/5 + int64(day) - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+//1970年1月1日以来的天数 -> 年,月,日(公历)
+func dayNumberDate(days int64) (year, month, day int) {
+	z := days + 719468
+	era := floorDiv(z, 146097)
+	doe := z - era*146097                                  //[0, 146096]
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 //[0, 399]
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               //[0, 365]
+	mp := (5*doy + 2) / 153                                //3月为0
+	day = int(doy-(153*mp+2)/5) + 1
+	month = int(mp+2)%12 + 1
+	year = int(yoe + era*400)
+	if month <= 2 {
+		year += 1
+	}
+	return
+}
+
+//1970年1月1日以来的天数 -> 星期(1-7)
+func dayNumberWeekdayA(days int64) int {
+	return int((days+3)-floorDiv(days+3, 7)*7) + 1
+}
+
 func checkClock(hour, min, sec int) error {
 	if hour > 23 || hour < 0 {
-		return NewError("check date clock error: out of range hour=%v", hour)
+		return newRangeError("hour", int64(hour), 0, 23)
 	}
 	if min < 0 || min > 59 {
-		return NewError("check date clock error: out of range min=%v", min)
+		return newRangeError("min", int64(min), 0, 59)
 	}
 	if sec < 0 || sec > 59 {
-		return NewError("check date clock error: out of range sec=%v", sec)
+		return newRangeError("sec", int64(sec), 0, 59)
 	}
 	return nil
 }
 
 func checkDateClock(year, month, day, hour, min, sec int) error {
-	if year > 9999 || year < 1 {
-		return NewError("check date clock error: out of range year=%v", year)
+	if int64(year) > maxYear || int64(year) < minYear {
+		return newRangeError("year", int64(year), minYear, maxYear)
 	}
 	if month > 12 || month < 1 {
-		return NewError("check date clock error: out of range month=%v", month)
+		return newRangeError("month", int64(month), 1, 12)
 	}
-	if day < 1 || day > 31 {
-		return NewError("check date clock error: out of range day=%v", day)
+	maxDay := norMonth[month-1]
+	if leapYear(year) {
+		maxDay = leapMonth[month-1]
 	}
-
-	m := month % 7
-	if m == 0 {
-		m = 1
-	}
-	if month == 2 {
-		if leapYear(year) {
-			if day > 29 {
-				return NewError("check date clock error: out of range day=%v", day)
-			}
-		} else {
-			if day > 28 {
-				return NewError("check date clock error: out of range day=%v", day)
-			}
-		}
-	} else {
-		if m%2 == 1 {
-			if day > 31 {
-				return NewError("check date clock error: out of range day=%v", day)
-			}
-		} else {
-			if day > 30 {
-				return NewError("check date clock error: out of range day=%v", day)
-			}
-		}
+	if day < 1 || day > maxDay {
+		return newRangeError("day", int64(day), 1, int64(maxDay))
 	}
 	return checkClock(hour, min, sec)
 }
@@ -83,36 +97,131 @@ func checkDateClock(year, month, day, hour, min, sec int) error {
 //@param:       formatter string "格式化模板" 如: "%Y/%m/%d %H:%M:%S", "%Y-%m-%d %H:%M:%S", "%Y%m%d%H%M%S"
 //@param:       string "日期时间字符串"
 func DateClockToFormat(year, month, day, hour, min, sec int, formatter string) string {
+	return DateClockToFormatLocale(year, month, day, hour, min, sec, formatter, LocaleEnglish)
+}
+
+//@description: 年,月,日,时,分,秒 -> 按语言环境格式化为日期时间字符串
+//@param:       year, month, day, hour, min, sec "年,月,日,时,分,秒"
+//@param:       formatter string "格式化模板" 数字指令前加O使用中文数字 如: "%OY年%Om月%Od日 %p%OI时%OM分", %z 总是为+0000
+//@param:       locale *Locale "语言环境" 如: LocaleEnglish, LocaleChinese
+//@param:       string "日期时间字符串"
+func DateClockToFormatLocale(year, month, day, hour, min, sec int, formatter string, locale *Locale) string {
+	return formatDateClock(year, month, day, hour, min, sec, 0, formatter, locale)
+}
+
+//年,月,日,时,分,秒 -> 格式化为日期时间字符串, offset为 %z 使用的时区偏移秒数
+func formatDateClock(year, month, day, hour, min, sec int, offset int64, formatter string, locale *Locale) string {
+	days := dateDayNumber(year, month, day)
+	fields := formatFields{year: year, month: month, day: day, hour: hour, min: min, sec: sec,
+		days: days, yDay: int(days-dateDayNumber(year, 1, 1)) + 1, offset: offset}
+	return fields.format(formatter, locale)
+}
+
+//格式化使用的字段, 所有格式化(DateTime, Date, Clock, 历法)共用
+type formatFields struct {
+	year, month, day, hour, min, sec int
+	days                             int64    //1970年1月1日以来的天数, 用于星期
+	yDay                             int      //年中的第几天(从1开始)
+	offset                           int64    //%z 使用的时区偏移秒数
+	cal                              Calendar //非公历的历法, %b %B 使用历法中的月份名称, nil为公历
+}
+
+//月份名称, 历法的月份名称与公历不同(如: 伊斯兰历, 希伯来历)时使用历法中的名称, 月份超出范围时为数字
+func (my *formatFields) appendMonthName(buf *[]byte, names *[12]string) {
+	inRange := my.month >= 1 && my.month <= 12
+	if my.cal != nil {
+		if name := my.cal.MonthName(my.year, my.month); !inRange || name != monthNames[my.month-1] {
+			*buf = append(*buf, name...)
+			return
+		}
+	}
+	if inRange {
+		*buf = append(*buf, names[my.month-1]...)
+	} else {
+		appendLocaleNumber(buf, my.month, 2, LocaleEnglish, false)
+	}
+}
+
+//1970年1月1日以来的第N天在年中的星期数(00-53), yDay为年中第几天
+//第一个 firstDay(星期1或星期天(7)) 所在的周为第1周, 之前为第0周
+func dayNumberYearWeekNum(days int64, yDay, firstDay int) int {
+	//距本周第一天的天数
+	since := int(floorMod(int64(dayNumberWeekdayA(days)-firstDay), 7))
+	return (yDay - 1 + 7 - since) / 7
+}
+
+func (my *formatFields) format(formatter string, locale *Locale) string {
 	var theTime []byte
 	length := len(formatter)
 	for i := 0; i < length; {
 		c := formatter[i]
-		if c == '%' {
-			if i+1 == length {
-				break
-			}
-			c2 := formatter[i+1]
-			switch c2 {
-			case 'Y': //四位数的年份表示（0000-9999）
-				ItoAW(&theTime, year, 4)
-			case 'm': //月份（01-12）
-				ItoAW(&theTime, month, 2)
-			case 'd': //月内中的一天（0-31）
-				ItoAW(&theTime, day, 2)
-			case 'H': //24小时制小时数（0-23）
-				ItoAW(&theTime, hour, 2)
-			case 'M': //分钟数（00=59）
-				ItoAW(&theTime, min, 2)
-			case 'S': //秒（00-59）
-				ItoAW(&theTime, sec, 2)
-			default:
-				theTime = append(theTime, c2)
-			}
-			i += 2
-		} else {
+		if c != '%' || i+1 == length {
 			theTime = append(theTime, c)
 			i += 1
+			continue
+		}
+		c2 := formatter[i+1]
+		alt := c2 == 'O' && i+2 < length
+		if alt {
+			i += 1
+			c2 = formatter[i+1]
+		}
+		week := dayNumberWeekdayA(my.days)
+		switch c2 {
+		case 'Y': //四位数的年份表示（0000-9999）, 公元前为负数 如: -0044, 超过9999时为实际位数
+			appendLocaleNumber(&theTime, my.year, 4, locale, alt)
+		case 'y': //两位数的年份表示（00-99）
+			appendLocaleNumber(&theTime, int(floorMod(int64(my.year), 100)), 2, locale, alt)
+		case 'm': //月份（01-12）
+			appendLocaleNumber(&theTime, my.month, 2, locale, alt)
+		case 'q': //季度（1-4）
+			appendLocaleNumber(&theTime, monthQuarter(my.month), 1, locale, alt)
+		case 'b': //月份缩写 如: Jan
+			my.appendMonthName(&theTime, &locale.ShortMonths)
+		case 'B': //月份全称 如: January
+			my.appendMonthName(&theTime, &locale.Months)
+		case 'd': //月内中的一天（0-31）
+			appendLocaleNumber(&theTime, my.day, 2, locale, alt)
+		case 'e': //月内中的一天, 不足两位前面补空格（ 1-31）
+			appendSpaceDay(&theTime, my.day, locale, alt)
+		case 'j': //一年内的第几天（001-366）
+			appendLocaleNumber(&theTime, my.yDay, 3, locale, alt)
+		case 'a': //星期缩写 如: Mon
+			theTime = append(theTime, locale.ShortWeekdays[week-1]...)
+		case 'A': //星期全称 如: Monday
+			theTime = append(theTime, locale.Weekdays[week-1]...)
+		case 'w': //星期（0-6），星期天为星期的开始
+			appendLocaleNumber(&theTime, week%7, 1, locale, alt)
+		case 'U': //一年中的星期数（00-53）星期天为星期的开始
+			appendLocaleNumber(&theTime, dayNumberYearWeekNum(my.days, my.yDay, 7), 2, locale, alt)
+		case 'W': //一年中的星期数（00-53）星期一为星期的开始
+			appendLocaleNumber(&theTime, dayNumberYearWeekNum(my.days, my.yDay, 1), 2, locale, alt)
+		case 'H': //24小时制小时数（0-23）
+			appendLocaleNumber(&theTime, my.hour, 2, locale, alt)
+		case 'I': //12小时制小时数（01-12）
+			hour12 := my.hour % 12
+			if hour12 == 0 {
+				hour12 = 12
+			}
+			appendLocaleNumber(&theTime, hour12, 2, locale, alt)
+		case 'p': //上午或下午 如: AM, PM
+			theTime = append(theTime, locale.Meridiem[my.hour/12]...)
+		case 'M': //分钟数（00=59）
+			appendLocaleNumber(&theTime, my.min, 2, locale, alt)
+		case 'S': //秒（00-59）
+			appendLocaleNumber(&theTime, my.sec, 2, locale, alt)
+		case 'z': //时区偏移 如: +0800
+			appendZoneOffset(&theTime, my.offset)
+		case '%': //百分号
+			theTime = append(theTime, '%')
+		default: //无法识别的指令原样输出
+			theTime = append(theTime, '%')
+			if alt {
+				theTime = append(theTime, 'O')
+			}
+			theTime = append(theTime, c2)
 		}
+		i += 2
 	}
 	return string(theTime)
 }
@@ -130,50 +239,26 @@ func DateClockToYmdHMS(year, month, day, hour, min, sec int) string {
 //@return:      unix int64 "秒级时间戳"
 //@return:      yDay "一年中第几天"
 //@return:      daySecond "一天中第几秒"
-//@return:      error "错误信息"
+//@return:      error "错误信息" 字段超出范围时为 *RangeError
 func DateClockToUnix(year, month, day, hour, min, sec int, zone TimeZone) (unix int64, yDay int, daySecond int, err error) {
+	return dateClockToUnix(year, month, day, hour, min, sec, zone.Offset())
+}
+
+//年,月,日,时,分,秒 -> 按时区偏移秒数转换为秒级时间戳
+func dateClockToUnix(year, month, day, hour, min, sec int, offset int64) (unix int64, yDay int, daySecond int, err error) {
 	err = checkDateClock(year, month, day, hour, min, sec)
 	if err != nil {
-		err = NewError("date clock to unix error: time=%v, err=%v", DateClockToYmdHMS(year, month, day, hour, min, sec), err)
 		return
 	}
 
-	nCha := year - 1970
-	var neg bool
-	if nCha < 0 {
-		nCha = -nCha
-		neg = true
-	}
-
-	nYear4 := nCha >> 2
-	nYearMod := nCha % 4
-	nDays := nYear4 * fourYears
-	pMonth := &norMonth
-	if nYearMod == 1 {
-		nDays += firstYears
-	} else if nYearMod == 2 {
-		nDays += secondYears
-		if !neg {
-			pMonth = &leapMonth
-		}
-	} else if nYearMod == 3 {
-		nDays += thirdYears
-	}
-
-	if neg {
-		nDays = -nDays
-	}
-
-	//var yDay int
-	for i := 0; i < month-1; i++ {
-		nDays += pMonth[i]
-		yDay += pMonth[i]
+	nDays := dateDayNumber(year, month, day)
+	if nDays < minDayNumber || nDays > maxDayNumber {
+		err = newRangeError("days", nDays, minDayNumber, maxDayNumber)
+		return
 	}
-
-	nDays += day - 1
+	yDay = int(nDays-dateDayNumber(year, 1, 1)) + 1
 	daySecond = hour*hourSec + min*minSec + sec
-	unix = int64(nDays*daySec+daySecond) - zone.Offset()
-	//return unix, yDay, daySecond, nil
+	unix = nDays*daySec + int64(daySecond) - offset
 	return
 }
 
@@ -185,15 +270,7 @@ func DateClockToUnix(year, month, day, hour, min, sec int, zone TimeZone) (unix
 //@return:      unix int64 "秒级时间戳"
 //@return:      error "错误信息"
 func FormatToUnix(s, formatter string, zone TimeZone, extend bool) (unix int64, err error) {
-	year, month, day, hour, min, sec, err := FormatToDateClock(s, formatter, extend)
-	if err != nil {
-		return 0, err
-	}
-	unix, _, _, err = DateClockToUnix(year, month, day, hour, min, sec, zone)
-	if err != nil {
-		return 0, err
-	}
-	return unix, nil
+	return FormatToUnixOptions(s, formatter, zone, extendOptions(extend))
 }
 
 //@description: 标准日期时间字符串 -> 转换为秒级时间戳
@@ -213,57 +290,16 @@ func YmdHMSToUnix(s string, zone TimeZone, extend bool) (unix int64, err error)
 //@return:      yDay "一年中第几天"
 //@return:      daySecond "一天中第几秒"
 func UnixToDateClock(unix int64, zone TimeZone) (year, month, day, hour, min, sec, yDay, daySecond int) {
-	unixLocal := unix + zone.Offset()
-	var nRemain int
-	if unixLocal < 0 {
-		nUnixSec := -unixLocal
-		nDays := int(nUnixSec / daySec)
-		daySecond = (daySec - int(nUnixSec-int64(nDays*daySec))) % daySec
-		nYear4 := nDays/fourYears + 1
-		nRemain = nYear4*fourYears - nDays
-		if daySecond == 0 {
-			nRemain += 1
-		}
-		year = 1970 - nYear4<<2
-	} else {
-		nDays := int(unixLocal / daySec)
-		daySecond = int(unixLocal - int64(nDays*daySec))
-		nYear4 := nDays / fourYears
-		nRemain = nDays - nYear4*fourYears + 1
-		year = 1970 + nYear4<<2
-	}
-	pMonth := &norMonth
-	if nRemain <= firstYears {
+	return unixToDateClock(unix, zone.Offset())
+}
 
-	} else if nRemain <= secondYears {
-		year += 1
-		nRemain -= firstYears
-	} else if nRemain <= thirdYears {
-		year += 2
-		nRemain -= secondYears
-		pMonth = &leapMonth
-	} else if nRemain <= fourYears {
-		year += 3
-		nRemain -= thirdYears
-	} else {
-		year += 4
-		nRemain -= fourYears
-	}
-	yDay = nRemain
-	var nTemp int
-	for i := 0; i < 12; i++ {
-		nTemp = nRemain - pMonth[i]
-		if nTemp < 1 {
-			month = i + 1
-			if nTemp == 0 {
-				day = pMonth[i]
-			} else {
-				day = nRemain
-			}
-			break
-		}
-		nRemain = nTemp
-	}
+//秒级时间戳 -> 按时区偏移秒数转为 年,月,日,时,分,秒
+func unixToDateClock(unix, offset int64) (year, month, day, hour, min, sec, yDay, daySecond int) {
+	unixLocal := unix + offset
+	nDays := floorDiv(unixLocal, daySec)
+	daySecond = int(unixLocal - nDays*daySec)
+	year, month, day = dayNumberDate(nDays)
+	yDay = int(nDays-dateDayNumber(year, 1, 1)) + 1
 	hour = daySecond / hourSec
 	inHourSec := daySecond - hour*hourSec
 	min = inHourSec / minSec
@@ -274,186 +310,32 @@ func UnixToDateClock(unix int64, zone TimeZone) (year, month, day, hour, min, se
 //@description: 日期时间字符串中获取 -> 年,月,日,时,分,秒
 //@param:       s string "日期时间字符串"
 //@param:       formatter string "格式化字符串"
-//@param:       extend bool "是否启用扩展模式"
+//@param:       extend bool "是否启用扩展模式" 更多选项使用 FormatToDateClockOptions
 //              扩展模式: 可以识别非标准日期时间字符串 如: 2020/1/1 0:1:1
 //              非扩展模式: 只能识别标准日期时间字符串 如: 2020/01/01 00:01:01
 //@return:      year, month, day, hour, min, sec int "日期时间"
-//@return:      error "错误信息"
+//@return:      error "错误信息" 解析失败时为 *ParseError
 func FormatToDateClock(s, formatter string, extend bool) (year, month, day, hour, min, sec int, err error) {
-	if extend {
-		return formatToDateClockEx(s, formatter)
-	} else {
-		return formatToDateClock(s, formatter)
-	}
+	return FormatToDateClockOptions(s, formatter, extendOptions(extend))
 }
 
-func formatToDateClock(s, formatter string) (year, month, day, hour, min, sec int, err error) {
-	defer Exception(func(stack string, e error) {
-		err = NewError("format to date clock error: %v, time=%v \n%v", e, s, stack)
-	})
-	var pos int
-	var pos2 int
-	length := len(formatter)
-	sLen := len(s)
-	for i := 0; i < length; {
-		c := formatter[i]
-		if c == '%' {
-			if i+1 == length {
-				break
-			}
-			c2 := formatter[i+1]
-
-			switch c2 {
-			case 'Y': //四位数的年份表示（0000-9999）
-				pos2 = pos + 4
-				if pos2 > sLen {
-					err = NewError("format to date clock format length error: to year, time=%v, formatter=%v", s, formatter)
-					return
-				}
-				c3 := s[pos:pos2]
-				pos = pos2
-				year, err = strconv.Atoi(c3)
-				if err != nil {
-					err = NewError("format to date clock param error: year=%v, time=%v", c3, s)
-					return
-				}
-			case 'm': //月份（01-12）
-				pos2 = pos + 2
-				if pos2 > sLen {
-					err = NewError("format to date clock format length error: to month, time=%v, formatter=%v", s, formatter)
-					return
-				}
-				c3 := s[pos:pos2]
-				pos = pos2
-				month, err = strconv.Atoi(c3)
-				if err != nil {
-					err = NewError("format to date clock param error: month=%v, time=%v", c3, s)
-					return
-				}
-			case 'd': //月内中的一天（01-31）
-				pos2 = pos + 2
-				if pos2 > sLen {
-					err = NewError("format to date clock format length error: to day, time=%v, formatter=%v", s, formatter)
-					return
-				}
-				c3 := s[pos:pos2]
-				pos = pos2
-				day, err = strconv.Atoi(c3)
-				if err != nil {
-					err = NewError("format to date clock param error: day=%v, time=%v", c3, s)
-					return
-				}
-			case 'H': //24小时制小时数（00-23）
-				pos2 = pos + 2
-				if pos2 > sLen {
-					err = NewError("format to date clock format length error: to hour, time=%v, formatter=%v", s, formatter)
-					return
-				}
-				c3 := s[pos:pos2]
-				pos = pos2
-				hour, err = strconv.Atoi(c3)
-				if err != nil {
-					err = NewError("format to date clock param error: hour=%v, time=%v", c3, s)
-					return
-				}
-			case 'M': //分钟数（00=59）
-				pos2 = pos + 2
-				if pos2 > sLen {
-					err = NewError("format to date clock format length error: to min, time=%v, formatter=%v", s, formatter)
-					return
-				}
-				c3 := s[pos:pos2]
-				pos = pos2
-				min, err = strconv.Atoi(c3)
-				if err != nil {
-					err = NewError("format to date clock param error: min=%v, time=%v", c3, s)
-					return
-				}
-			case 'S': //秒（00-59）
-				pos2 = pos + 2
-				if pos2 > sLen {
-					err = NewError("format to date clock format length error: to sec, time=%v, formatter=%v", s, formatter)
-				}
-				c3 := s[pos:pos2]
-				pos = pos2
-				sec, err = strconv.Atoi(c3)
-				if err != nil {
-					err = NewError("format to date clock param error: sec=%v, time=%v", c3, s)
-					return
-				}
-			default:
-				err = NewError("format to date clock formatter error: %v, time=%v, formatter=%v", formatter[i:i+2], s, formatter)
-				return
-			}
-			i += 2
-		} else {
-			if formatter[i] != s[pos] {
-				err = NewError("format to date clock separator error: %v, time=%v, formatter=%v", string(s[pos]), s, formatter)
-				return
-			}
-			i += 1
-			pos += 1
-		}
-	}
-
-	err = checkDateClock(year, month, day, hour, min, sec)
-	if err != nil {
-		return 0, 0, 0, 0, 0, 0, NewError("format to date clock check error: time=%v, err=%v", s, err)
+//固定宽度的十进制数字, 不接受符号和空白, 溢出时返回false
+func fixedDigits(s string) (int, bool) {
+	if len(s) == 0 {
+		return 0, false
 	}
-	return
-}
-
-func formatToDateClockEx(s, formatter string) (year, month, day, hour, min, sec int, err error) {
-	defer Exception(func(stack string, e error) {
-		err = NewError("format to date clock ex exception: %v, time=%v \n%v", e, s, stack)
-	})
-	numbers := NewNextNumber(s)
-	var found bool
-
-	length := len(formatter)
-	var jump int
-	for i := 0; i < length; {
-		c := formatter[i]
-		if c == '%' {
-			if i+1 == length {
-				break
-			}
-			c2 := formatter[i+1]
-			switch c2 {
-			case 'Y': //四位数的年份表示（0000-9999）
-				year, found = numbers.Next(jump, 4)
-			case 'm': //月份（01-12）
-				month, found = numbers.Next(jump, 2)
-			case 'd': //月内中的一天（01-31）
-				day, found = numbers.Next(jump, 2)
-			case 'H': //24小时制小时数（00-23）
-				hour, found = numbers.Next(jump, 2)
-			case 'M': //分钟数（00=59）
-				min, found = numbers.Next(jump, 2)
-			case 'S': //秒（00-59）
-				sec, found = numbers.Next(jump, 2)
-			default:
-				err = NewError("format to date clock ex formatter error: %v, time=%v, formatter=%v", formatter[i:i+2], s, formatter)
-				return
-			}
-
-			if !found {
-				err = NewError("format to date clock ex found number error: time=%v, formatter=%v", s, formatter)
-				return
-			}
-			jump = 0
-			i += 2
-		} else {
-			jump += 1
-			i += 1
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
 		}
+		digit := int(s[i] - '0')
+		if n > (math.MaxInt-digit)/10 {
+			return 0, false
+		}
+		n = n*10 + digit
 	}
-
-	err = checkDateClock(year, month, day, hour, min, sec)
-	if err != nil {
-		err = NewError("format to date clock ex check error: time=%v, err=%v", s, err)
-	}
-	return
+	return n, true
 }
 
 //go:linkname now time.now
@@ -507,7 +389,7 @@ func UnixWeekdayB(unix int64, zone TimeZone) (week int) {
 	return
 }
 
-//@description: 返回时间戳年中的星期数, 星期1为一周的开始
+//@description: 返回时间戳年中的星期数, 星期1为一周的开始 与 %W 不同, %W 的周数使用 UnixYearWeekNumW
 //@param:       unix int64 "秒级时间戳"
 //@param:       zone TimeZone "时区"
 //@return:      int(0-53) "第几周"
@@ -518,7 +400,7 @@ func UnixYearWeekNumA(unix int64, zone TimeZone) int {
 	return nSecond / weekSec
 }
 
-//@description: 返回时间戳年中的星期数, 星期天为一周的开始
+//@description: 返回时间戳年中的星期数, 星期天为一周的开始 与 %U 不同, %U 的周数使用 UnixYearWeekNumU
 //@param:       unix int64 "秒级时间戳"
 //@param:       zone TimeZone "时区"
 //@return:      int(0-53) "第几周"
@@ -529,12 +411,35 @@ func UnixYearWeekNumB(unix int64, zone TimeZone) int {
 	return nSecond / weekSec
 }
 
+//@description: 返回时间戳年中的星期数, 星期1为一周的开始, 第一个星期1所在的周为第1周, 之前为第0周 与 %W 一致
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int(0-53) "第几周"
+func UnixYearWeekNumW(unix int64, zone TimeZone) int {
+	return unixYearWeekNum(unix, zone, 1)
+}
+
+//@description: 返回时间戳年中的星期数, 星期天为一周的开始, 第一个星期天所在的周为第1周, 之前为第0周 与 %U 一致
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区"
+//@return:      int(0-53) "第几周"
+func UnixYearWeekNumU(unix int64, zone TimeZone) int {
+	return unixYearWeekNum(unix, zone, 7)
+}
+
+//年中的星期数, 与 %W(firstDay为1) %U(firstDay为7) 一致
+func unixYearWeekNum(unix int64, zone TimeZone, firstDay int) int {
+	days := localDayNumber(unix, zone)
+	year, _, _ := dayNumberDate(days)
+	return dayNumberYearWeekNum(days, int(days-dateDayNumber(year, 1, 1))+1, firstDay)
+}
+
 //@description: 返回时间戳1970年1月1日以来的天数
 //@param:       unix int64 "秒级时间戳"
 //@param:       zone TimeZone "时区"
 //@return:      int64 "天数"
 func UnixDayNumber(unix int64, zone TimeZone) int64 {
-	return (unix + zone.Offset()) / int64(daySec)
+	return localDayNumber(unix, zone)
 }
 
 //@description: 返回时间戳所在年的1月1日0时的秒级时间戳
@@ -570,22 +475,20 @@ func UnixDayZeroHour(unix int64, zone TimeZone) int64 {
 	return unixLocal - unixLocal%daySec - zone.Offset()
 }
 
-//@description: 返回时间戳本小时的0分的秒级时间戳
+//@description: 返回时间戳本小时的0分的秒级时间戳, 按UTC的整小时对齐, 非整小时偏移的时区(如: +05:30)使用 UnixHourZeroMinZone
 //@param:       unix int64 "秒级时间戳"
 //@return:      int64 "秒级时间戳"
 func UnixHourZeroMin(unix int64) int64 {
-	if unix < 0 {
-		nSecond := (unix%hourSec + hourSec) % daySec
-		return unix - nSecond
-	}
-	return unix - unix%hourSec
+	return unix - floorMod(unix, hourSec)
+}
 
-	//unixLocal := unix + zone.offset
-	//if unixLocal < 0{
-	//	nSecond := (unixLocal % hourSec + hourSec) % daySec
-	//	return unixLocal - nSecond - zone.offset
-	//}
-	//return unixLocal - unixLocal % hourSec - zone.offset
+//@description: 返回时间戳在指定时区本小时的0分的秒级时间戳
+//@param:       unix int64 "秒级时间戳"
+//@param:       zone TimeZone "时区" 非整小时偏移的时区(如: +05:30)需按当地时间对齐
+//@return:      int64 "秒级时间戳"
+func UnixHourZeroMinZone(unix int64, zone TimeZone) int64 {
+	start, _ := UnixTruncate(unix, UnitHour, 1, zone)
+	return start
 }
 
 //@description: 返回时间戳当天特定时间的秒级时间戳
@@ -622,7 +525,7 @@ func UnixDayZeroHourNext(unix int64, days, hour, min, sec int, zone TimeZone) (i
 //@return:      error "错误信息"
 func UnixNextWeekDayA(unix int64, week int, hour, min, sec int, zone TimeZone) (int64, error) {
 	if week < 1 || week > 7 {
-		return 0, NewError("week out of range(1,7): %v", week)
+		return 0, newRangeError("week", int64(week), 1, 7)
 	}
 	w := UnixWeekdayA(unix, zone)
 	days := week - w
@@ -637,7 +540,7 @@ func UnixNextWeekDayA(unix int64, week int, hour, min, sec int, zone TimeZone) (
 //@return:      error "错误信息"
 func UnixNextWeekDayB(unix int64, week, hour, min, sec int, zone TimeZone) (int64, error) {
 	if week < 0 || week > 6 {
-		return 0, NewError("week out of range(0, 6): %v", week)
+		return 0, newRangeError("week", int64(week), 0, 6)
 	}
 	w := UnixWeekdayB(unix, zone)
 	days := week - w
@@ -652,7 +555,7 @@ func UnixNextWeekDayB(unix int64, week, hour, min, sec int, zone TimeZone) (int6
 //@return:      error "错误信息"
 func UnixFutureWeekDayA(unix int64, week, hour, min, sec int, zone TimeZone) (int64, error) {
 	if week < 1 || week > 7 {
-		return 0, NewError("week out of range(1,7): %v", week)
+		return 0, newRangeError("week", int64(week), 1, 7)
 	}
 	if err := checkClock(hour, min, sec); err != nil {
 		return 0, err
@@ -675,7 +578,7 @@ func UnixFutureWeekDayA(unix int64, week, hour, min, sec int, zone TimeZone) (in
 //@return:      error "错误信息"
 func UnixFutureWeekDayB(unix int64, week, hour, min, sec int, zone TimeZone) (int64, error) {
 	if week < 0 || week > 6 {
-		return 0, NewError("week out of range(0, 6): %v", week)
+		return 0, newRangeError("week", int64(week), 0, 6)
 	}
 	if err := checkClock(hour, min, sec); err != nil {
 		return 0, err
@@ -692,8 +595,13 @@ func UnixFutureWeekDayB(unix int64, week, hour, min, sec int, zone TimeZone) (in
 
 //返回时间戳的 格式化日期时间字符串
 func UnixToFormat(unix int64, zone TimeZone, formatter string) string {
+	return UnixToFormatLocale(unix, zone, formatter, LocaleEnglish)
+}
+
+//返回时间戳的 按语言环境格式化的日期时间字符串
+func UnixToFormatLocale(unix int64, zone TimeZone, formatter string, locale *Locale) string {
 	year, month, day, hour, min, sec, _, _ := UnixToDateClock(unix, zone)
-	return DateClockToFormat(year, month, day, hour, min, sec, formatter)
+	return formatDateClock(year, month, day, hour, min, sec, zone.Offset(), formatter, locale)
 }
 
 //返回时间戳的 标准日期时间字符串
@@ -0,0 +1,305 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/jingyanbin/timezone"
+)
+
+//time.Time 可表示的最小年份, 更早的年份只做往返校验
+const timeMinYear = -292277022399
+
+//对比用的年份: 负数年份, 0年, 1582年(儒略历改格里历, 本库为推算的公历), 世纪年, 边界年份
+var testYears = []int{timeMinYear, timeMinYear + 1, -1000000001, -4713, -401, -400, -101, -100, -5, -4, -1, 0, 1, 4,
+	100, 1581, 1582, 1583, 1600, 1900, 1969, 1970, 2000, 2024, 2100, 9999, 10000, 1000000000, maxYear - 1, maxYear}
+
+func TestDateDayNumber(t *testing.T) {
+	for _, year := range testYears {
+		for month := 1; month <= 12; month++ {
+			for day := 1; day <= GregorianCalendar.MonthDays(year, month); day++ {
+				days := dateDayNumber(year, month, day)
+				if days > maxDayNumber {
+					//秒级时间戳在maxYear年末溢出
+					continue
+				}
+				tm := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+				if want := tm.Unix() / daySec; days != want {
+					t.Fatalf("dateDayNumber(%v, %v, %v) = %v, want %v", year, month, day, days, want)
+				}
+				if y, m, d := dayNumberDate(days); y != year || m != month || d != day {
+					t.Fatalf("dayNumberDate(%v) = %v-%v-%v, want %v-%v-%v", days, y, m, d, year, month, day)
+				}
+				if week, want := dayNumberWeekdayA(days), (int(tm.Weekday())+6)%7+1; week != want {
+					t.Fatalf("dayNumberWeekdayA(%v) = %v, want %v", days, week, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDayNumberDate(t *testing.T) {
+	first := dateDayNumber(timeMinYear, 1, 1)
+	last := int64(maxDayNumber)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		days := first + r.Int63n(last-first)
+		if i%2 == 0 {
+			//近几千年内的日期
+			days = r.Int63n(4000*366) - 2000*366
+		}
+		tm := time.Date(1970, 1, 1+int(days), 0, 0, 0, 0, time.UTC)
+		year, month, day := dayNumberDate(days)
+		if year != tm.Year() || month != int(tm.Month()) || day != tm.Day() {
+			t.Fatalf("dayNumberDate(%v) = %v-%v-%v, want %v", days, year, month, day, tm)
+		}
+		if n := dateDayNumber(year, month, day); n != days {
+			t.Fatalf("dateDayNumber(%v, %v, %v) = %v, want %v", year, month, day, n, days)
+		}
+	}
+}
+
+func TestDayNumberRange(t *testing.T) {
+	for _, days := range []int64{minDayNumber, minDayNumber + 1, maxDayNumber - 1, maxDayNumber} {
+		year, month, day := dayNumberDate(days)
+		if int64(year) < minYear || int64(year) > maxYear {
+			t.Fatalf("dayNumberDate(%v) year %v out of [%v, %v]", days, year, int64(minYear), int64(maxYear))
+		}
+		if n := dateDayNumber(year, month, day); n != days {
+			t.Fatalf("dateDayNumber(%v, %v, %v) = %v, want %v", year, month, day, n, days)
+		}
+		for _, offset := range []int64{-maxZoneOffset, 0, maxZoneOffset} {
+			unix, _, _, err := dateClockToUnix(year, month, day, 12, 0, 0, offset)
+			if err != nil {
+				t.Fatalf("dateClockToUnix(%v, %v, %v) error: %v", year, month, day, err)
+			}
+			if y, m, d, h, _, _, _, _ := unixToDateClock(unix, offset); y != year || m != month || d != day || h != 12 {
+				t.Fatalf("unixToDateClock(%v, %v) = %v-%v-%v %v, want %v-%v-%v 12", unix, offset, y, m, d, h, year, month, day)
+			}
+		}
+	}
+	//超出范围的年份和日期
+	for _, c := range []struct{ year, month, day int }{{minYear - 1, 12, 31}, {minYear, 1, 1}, {maxYear, 12, 31}, {maxYear + 1, 1, 1}} {
+		_, _, _, err := dateClockToUnix(c.year, c.month, c.day, 0, 0, 0, 0)
+		if !errors.Is(err, ErrRange) {
+			t.Errorf("dateClockToUnix(%v, %v, %v) error = %v, want ErrRange", c.year, c.month, c.day, err)
+		}
+	}
+}
+
+func TestLocalDayNumber(t *testing.T) {
+	cst := time.FixedZone("CST", 8*hourSec)
+	r := rand.New(rand.NewSource(2))
+	unixes := []int64{0, -1, daySec - 8*hourSec - 1, daySec - 8*hourSec, -62135596800, -12219292800, 253402300799}
+	for i := 0; i < 10000; i++ {
+		unixes = append(unixes, r.Int63n(1<<40)-1<<39)
+	}
+	for _, unix := range unixes {
+		tm := time.Unix(unix, 0).In(cst)
+		want := dateDayNumber(tm.Year(), int(tm.Month()), tm.Day())
+		if days := localDayNumber(unix, Zones.E8); days != want {
+			t.Fatalf("localDayNumber(%v) = %v, want %v (%v)", unix, days, want, tm)
+		}
+	}
+}
+
+func TestUnixToDateClock(t *testing.T) {
+	cases := []struct {
+		unix                                              int64
+		year, month, day, hour, min, sec, yDay, daySecond int
+	}{
+		{0, 1970, 1, 1, 8, 0, 0, 1, 8 * hourSec},
+		{-8 * hourSec, 1970, 1, 1, 0, 0, 0, 1, 0},
+		{-8*hourSec - 1, 1969, 12, 31, 23, 59, 59, 365, daySec - 1},
+		{1599870615, 2020, 9, 12, 8, 30, 15, 256, 8*hourSec + 30*minSec + 15},
+		{1709225999, 2024, 3, 1, 0, 59, 59, 61, hourSec - 1},
+		{951753600, 2000, 2, 29, 0, 0, 0, 60, 0},
+		{253402271999, 9999, 12, 31, 23, 59, 59, 365, daySec - 1},
+		{-62135625600, 1, 1, 1, 0, 0, 0, 1, 0},
+		{-62135625601, 0, 12, 31, 23, 59, 59, 366, daySec - 1},
+		{-12219321600, 1582, 10, 15, 0, 0, 0, 288, 0},
+	}
+	for _, c := range cases {
+		year, month, day, hour, min, sec, yDay, daySecond := UnixToDateClock(c.unix, Zones.E8)
+		if year != c.year || month != c.month || day != c.day || hour != c.hour || min != c.min || sec != c.sec || yDay != c.yDay || daySecond != c.daySecond {
+			t.Errorf("UnixToDateClock(%v) = %v-%v-%v %v:%v:%v %v %v, want %v-%v-%v %v:%v:%v %v %v", c.unix,
+				year, month, day, hour, min, sec, yDay, daySecond, c.year, c.month, c.day, c.hour, c.min, c.sec, c.yDay, c.daySecond)
+		}
+	}
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 10000; i++ {
+		unix := r.Int63n(1<<40) - 1<<39
+		offset := r.Int63n(2*maxZoneOffset/minSec+1)*minSec - maxZoneOffset
+		tm := time.Unix(unix, 0).In(time.FixedZone("", int(offset)))
+		year, month, day, hour, min, sec, yDay, daySecond := unixToDateClock(unix, offset)
+		if year != tm.Year() || month != int(tm.Month()) || day != tm.Day() || hour != tm.Hour() || min != tm.Minute() ||
+			sec != tm.Second() || yDay != tm.YearDay() || daySecond != (hour*hourSec+min*minSec+sec) {
+			t.Fatalf("unixToDateClock(%v, %v) = %v-%v-%v %v:%v:%v %v, want %v", unix, offset, year, month, day, hour, min, sec, yDay, tm)
+		}
+	}
+}
+
+func TestDateClockToUnix(t *testing.T) {
+	cases := []struct {
+		year, month, day, hour, min, sec int
+		unix                             int64
+		field                            string //出错的字段, 为空时不出错
+	}{
+		{1970, 1, 1, 8, 0, 0, 0, ""},
+		{2020, 9, 12, 8, 30, 15, 1599870615, ""},
+		{2000, 2, 29, 0, 0, 0, 951753600, ""},
+		{0, 12, 31, 23, 59, 59, -62135625601, ""},
+		{1582, 10, 15, 0, 0, 0, -12219321600, ""},
+		{2021, 2, 29, 0, 0, 0, 0, "day"},
+		{1900, 2, 29, 0, 0, 0, 0, "day"},
+		{2020, 4, 31, 0, 0, 0, 0, "day"},
+		{2020, 1, 0, 0, 0, 0, 0, "day"},
+		{2020, 13, 1, 0, 0, 0, 0, "month"},
+		{2020, 0, 1, 0, 0, 0, 0, "month"},
+		{2020, 1, 1, 24, 0, 0, 0, "hour"},
+		{2020, 1, 1, -1, 0, 0, 0, "hour"},
+		{2020, 1, 1, 0, 60, 0, 0, "min"},
+		{2020, 1, 1, 0, 0, 60, 0, "sec"},
+		{maxYear + 1, 1, 1, 0, 0, 0, 0, "year"},
+		{minYear - 1, 1, 1, 0, 0, 0, 0, "year"},
+	}
+	for _, c := range cases {
+		unix, _, _, err := DateClockToUnix(c.year, c.month, c.day, c.hour, c.min, c.sec, Zones.E8)
+		if c.field != "" {
+			var rErr *RangeError
+			if !errors.As(err, &rErr) || rErr.Field != c.field {
+				t.Errorf("DateClockToUnix(%v-%v-%v %v:%v:%v) error = %v, want %v RangeError", c.year, c.month, c.day, c.hour, c.min, c.sec, err, c.field)
+			}
+			continue
+		}
+		if err != nil || unix != c.unix {
+			t.Errorf("DateClockToUnix(%v-%v-%v %v:%v:%v) = %v, %v, want %v", c.year, c.month, c.day, c.hour, c.min, c.sec, unix, err, c.unix)
+		}
+	}
+}
+
+func TestUnixWeekdayA(t *testing.T) {
+	cases := []struct {
+		date     string
+		week     int //星期(1-7)
+		weekNumW int //%W
+		weekNumU int //%U
+	}{
+		{"2020-01-01", 3, 0, 0},
+		{"2020-01-05", 7, 0, 1},
+		{"2020-01-06", 1, 1, 1},
+		{"2023-01-01", 7, 0, 1},
+		{"2023-01-02", 1, 1, 1},
+		{"2018-01-01", 1, 1, 0},
+		{"2017-12-31", 7, 52, 53},
+		{"2020-12-31", 4, 52, 52},
+		{"2012-12-30", 7, 52, 53},
+		{"2012-12-31", 1, 53, 53},
+		{"1969-12-31", 3, 52, 52},
+		{"1900-01-07", 7, 1, 1},
+	}
+	cst := time.FixedZone("CST", 8*hourSec)
+	for _, c := range cases {
+		day, err := time.ParseInLocation("2006-01-02", c.date, cst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//当天的0时和23:59:59
+		for _, unix := range []int64{day.Unix(), day.Unix() + daySec - 1} {
+			if week := UnixWeekdayA(unix, Zones.E8); week != c.week {
+				t.Errorf("UnixWeekdayA(%v) = %v, want %v", c.date, week, c.week)
+			}
+			if week := UnixWeekdayB(unix, Zones.E8); week != c.week%7 {
+				t.Errorf("UnixWeekdayB(%v) = %v, want %v", c.date, week, c.week%7)
+			}
+			if n := UnixYearWeekNumW(unix, Zones.E8); n != c.weekNumW {
+				t.Errorf("UnixYearWeekNumW(%v) = %v, want %v", c.date, n, c.weekNumW)
+			}
+			if n := UnixYearWeekNumU(unix, Zones.E8); n != c.weekNumU {
+				t.Errorf("UnixYearWeekNumU(%v) = %v, want %v", c.date, n, c.weekNumU)
+			}
+			if s := UnixToFormat(unix, Zones.E8, "%W %U"); s != fmt.Sprintf("%02d %02d", c.weekNumW, c.weekNumU) {
+				t.Errorf("UnixToFormat(%v, %%W %%U) = %v, want %02d %02d", c.date, s, c.weekNumW, c.weekNumU)
+			}
+		}
+	}
+	//与 time.Time 的星期, %W %U 的定义, 以及 UnixYearWeekNumA/B 原有的公式(1月1日的星期数加年中的天数)对比
+	day := time.Date(1999, 12, 1, 12, 0, 0, 0, cst)
+	for i := 0; i < 3000; i++ {
+		unix := day.AddDate(0, 0, i).Unix()
+		tm := time.Unix(unix, 0).In(cst)
+		weekB := int(tm.Weekday())
+		if week := UnixWeekdayA(unix, Zones.E8); week != (weekB+6)%7+1 {
+			t.Fatalf("UnixWeekdayA(%v) = %v, want %v", tm, week, (weekB+6)%7+1)
+		}
+		yDay0 := tm.YearDay() - 1
+		if n, want := UnixYearWeekNumW(unix, Zones.E8), (yDay0+7-(weekB+6)%7)/7; n != want {
+			t.Fatalf("UnixYearWeekNumW(%v) = %v, want %v", tm, n, want)
+		}
+		if n, want := UnixYearWeekNumU(unix, Zones.E8), (yDay0+7-weekB)/7; n != want {
+			t.Fatalf("UnixYearWeekNumU(%v) = %v, want %v", tm, n, want)
+		}
+		jan1 := int(time.Date(tm.Year(), 1, 1, 0, 0, 0, 0, cst).Weekday())
+		if n, want := UnixYearWeekNumA(unix, Zones.E8), (yDay0+(jan1+6)%7+1)/7; n != want {
+			t.Fatalf("UnixYearWeekNumA(%v) = %v, want %v", tm, n, want)
+		}
+		if n, want := UnixYearWeekNumB(unix, Zones.E8), (yDay0+jan1)/7; n != want {
+			t.Fatalf("UnixYearWeekNumB(%v) = %v, want %v", tm, n, want)
+		}
+	}
+}
+
+//格式化后再解析得到原来的时间, 并与 time.Time 的格式化结果一致
+func TestFormatParseRoundTrip(t *testing.T) {
+	const layout = "%Y-%m-%d %H:%M:%S %z"
+	years := []int{-2000, -44, -1, 0, 1, 99, 100, 999, 1000, 1582, 1899, 1900, 1970, 1999, 2000, 2038, 2100, 2400, 9999, 10000, 123456}
+	offsets := []int64{-maxZoneOffset, -12 * hourSec, -(9*hourSec + 30*minSec), -5 * hourSec, 0, 5*hourSec + 45*minSec, 8 * hourSec, maxZoneOffset}
+	r := rand.New(rand.NewSource(4))
+	for _, year := range years {
+		for _, offset := range offsets {
+			zone := time.FixedZone("", int(offset))
+			tm := time.Date(year, time.Month(r.Intn(12)+1), r.Intn(31)+1, r.Intn(24), r.Intn(60), r.Intn(60), 0, zone)
+			unix := tm.Unix()
+			y, m, d, h, mi, s, _, _ := unixToDateClock(unix, offset)
+			str := formatDateClock(y, m, d, h, mi, s, offset, layout, LocaleEnglish)
+			if year >= 0 && year <= 9999 {
+				if want := tm.Format("2006-01-02 15:04:05 -0700"); str != want {
+					t.Fatalf("formatDateClock(%v) = %q, want %q", tm, str, want)
+				}
+			}
+			got, err := FormatToUnixOptions(str, layout, Zones.E8, StrictParse)
+			if err != nil || got != unix {
+				t.Fatalf("FormatToUnixOptions(%q) = %v, %v, want %v", str, got, err, unix)
+			}
+		}
+	}
+}
+
+func FuzzDateClockToFormat(f *testing.F) {
+	f.Add(int64(1599870615), int64(8*hourSec), "%Y-%m-%d %H:%M:%S %z")
+	f.Add(int64(-62135625601), int64(0), "%a %A %b %B %e %j %U %W %w %p %I %q %y")
+	f.Add(int64(0), int64(-maxZoneOffset), "%OY年%Om月%Od日 %Ok %%%")
+	f.Fuzz(func(t *testing.T, unix, offset int64, formatter string) {
+		if unix > 1<<50 || unix < -1<<50 || offset > maxZoneOffset || offset < -maxZoneOffset {
+			return
+		}
+		offset -= offset % minSec //%z 精确到分
+		year, month, day, hour, min, sec, _, _ := unixToDateClock(unix, offset)
+		for _, locale := range []*Locale{LocaleEnglish, LocaleChinese} {
+			s := formatDateClock(year, month, day, hour, min, sec, offset, formatter, locale)
+			if !strings.Contains(formatter, "%") && s != formatter {
+				t.Fatalf("formatDateClock(%q) = %q, want formatter unchanged", formatter, s)
+			}
+		}
+		//只包含数字指令和分隔符时可以解析回原来的时间
+		const layout = "%Y/%m/%d %H:%M:%S %z"
+		s := formatDateClock(year, month, day, hour, min, sec, offset, layout, LocaleEnglish)
+		if got, err := FormatToUnixOptions(s, layout, Zones.E8, StrictParse); err != nil || got != unix {
+			t.Fatalf("FormatToUnixOptions(%q) = %v, %v, want %v", s, got, err, unix)
+		}
+	})
+}